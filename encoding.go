@@ -0,0 +1,588 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+var errNilSortedSet = errors.New("goset: SortedSet of unordered elements must be created by NewSortedSetFunc before decoding")
+
+// defaultCompare returns a comparator in asc order if the kind of T is ordered, otherwise returns nil.
+// It's used to decode SortedSets which aren't created by NewSortedSet, such as fields of decoded structs
+func defaultCompare[T comparable]() func(a, b T) int {
+	sign := func(lt, gt bool) int {
+		switch {
+		case lt:
+			return -1
+		case gt:
+			return 1
+		}
+		return 0
+	}
+	switch reflect.TypeOf((*T)(nil)).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b T) int {
+			x, y := reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int()
+			return sign(x < y, x > y)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b T) int {
+			x, y := reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint()
+			return sign(x < y, x > y)
+		}
+	case reflect.Float32, reflect.Float64:
+		// NaN is less than any other number, as compare.Compare does
+		return func(a, b T) int {
+			x, y := reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float()
+			return sign(x < y || x != x && y == y, x > y || y != y && x == x)
+		}
+	case reflect.String:
+		return func(a, b T) int {
+			x, y := reflect.ValueOf(a).String(), reflect.ValueOf(b).String()
+			return sign(x < y, x > y)
+		}
+	}
+	return nil
+}
+
+func marshalJSON[T any](vals []T) ([]byte, error) {
+	if vals == nil {
+		// encode as [] instead of null
+		vals = []T{}
+	}
+	return json.Marshal(vals)
+}
+
+func unmarshalJSON[T any](data []byte) ([]T, error) {
+	var vals []T
+	err := json.Unmarshal(data, &vals)
+	return vals, err
+}
+
+func gobEncode[T any](vals []T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(vals); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode[T any](data []byte) ([]T, error) {
+	var vals []T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&vals)
+	return vals, err
+}
+
+// loader is implemented by sets that can replace all elements with decoded ones
+type loader[T comparable] interface {
+	load(vals []T) error
+}
+
+var (
+	_ loader[int] = (*Set[int])(nil)
+	_ loader[int] = (*FifoSet[int])(nil)
+	_ loader[int] = (*FiloSet[int])(nil)
+	_ loader[int] = (*SortedSet[int])(nil)
+	_ loader[int] = (*ShardedSet[int])(nil)
+	_ loader[int] = (*UnsafeSet[int])(nil)
+	_ loader[int] = (*UnsafeSortedSet[int])(nil)
+	_ loader[int] = (*SyncSet[int])(nil)
+)
+
+// isJSONNull returns whether data is JSON null, which is decoded as a no-op by convention
+func isJSONNull(data []byte) bool {
+	return string(data) == "null"
+}
+
+// load replaces all elements with vals
+func (s *Set[T]) load(vals []T) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.u.Clear()
+	s.shared = false
+	s.u.Add(vals...)
+	return nil
+}
+
+// MarshalJSON encodes Set as a JSON array
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s.ToList())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes Set as a JSON array
+func (s *Set[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *Set[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes Set by gob
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return gobEncode(s.ToList())
+}
+
+// GobDecode replaces elements with decoded ones
+func (s *Set[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// load replaces all elements with vals, which are added in order
+func (s *FifoSet[T]) load(vals []T) error {
+	if s.linearSet == nil {
+		s.linearSet = newLinearSet[T](addFifo[T])
+	}
+	s.bounded(func() {
+		s.reset()
+		pushBackAll(s.linearSet, vals)
+	})
+	return nil
+}
+
+// MarshalJSON encodes FifoSet as a JSON array from the first in element to the last in element
+func (s *FifoSet[T]) MarshalJSON() ([]byte, error) {
+	if s.linearSet == nil {
+		return marshalJSON[T](nil)
+	}
+	return marshalJSON(s.ToList())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *FifoSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes FifoSet as a JSON array
+func (s *FifoSet[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *FifoSet[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes FifoSet by gob
+func (s *FifoSet[T]) GobEncode() ([]byte, error) {
+	if s.linearSet == nil {
+		return gobEncode[T](nil)
+	}
+	return gobEncode(s.ToList())
+}
+
+// GobDecode replaces elements with decoded ones
+func (s *FifoSet[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// load replaces all elements with vals, which are listed from the last in element to the first in element
+func (s *FiloSet[T]) load(vals []T) error {
+	if s.linearSet == nil {
+		s.linearSet = newLinearSet[T](addFilo[T])
+	}
+	// drop duplicate elements but the first ones, so that the first one of vals becomes the top
+	seen := make(map[T]struct{}, len(vals))
+	stack := make([]T, 0, len(vals))
+	for _, v := range vals {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			stack = append(stack, v)
+		}
+	}
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	s.reset()
+	pushFrontAll(s.linearSet, stack)
+	return nil
+}
+
+// MarshalJSON encodes FiloSet as a JSON array from the last in element to the first in element,
+// which is the same as ToList
+func (s *FiloSet[T]) MarshalJSON() ([]byte, error) {
+	if s.linearSet == nil {
+		return marshalJSON[T](nil)
+	}
+	return marshalJSON(s.ToList())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *FiloSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes FiloSet as a JSON array
+func (s *FiloSet[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *FiloSet[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes FiloSet by gob
+func (s *FiloSet[T]) GobEncode() ([]byte, error) {
+	if s.linearSet == nil {
+		return gobEncode[T](nil)
+	}
+	return gobEncode(s.ToList())
+}
+
+// GobDecode replaces elements with decoded ones
+func (s *FiloSet[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// load replaces all elements with vals.
+// A SortedSet which isn't created by NewSortedSet or NewSortedSetFunc orders elements in asc order if they're ordered
+func (s *SortedSet[T]) load(vals []T) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.u.list == nil {
+		compare := defaultCompare[T]()
+		if compare == nil {
+			return errNilSortedSet
+		}
+		s.u.list = newSkipList[T](compare)
+	}
+	s.u.list.clear()
+	for _, v := range vals {
+		s.u.list.insert(v)
+	}
+	return nil
+}
+
+// MarshalJSON encodes SortedSet as a JSON array in asc order
+func (s *SortedSet[T]) MarshalJSON() ([]byte, error) {
+	if s.u.list == nil {
+		return marshalJSON[T](nil)
+	}
+	return marshalJSON(s.ToList())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped.
+// A zero SortedSet orders elements in asc order, it returns an error if elements aren't ordered,
+// then the SortedSet must be created by NewSortedSetFunc to know how to order elements
+func (s *SortedSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes SortedSet as a JSON array
+func (s *SortedSet[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *SortedSet[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes SortedSet by gob
+func (s *SortedSet[T]) GobEncode() ([]byte, error) {
+	if s.u.list == nil {
+		return gobEncode[T](nil)
+	}
+	return gobEncode(s.ToList())
+}
+
+// GobDecode replaces elements with decoded ones.
+// A zero SortedSet orders elements in asc order, it returns an error if elements aren't ordered,
+// then the SortedSet must be created by NewSortedSetFunc to know how to order elements
+func (s *SortedSet[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// load replaces all elements with vals.
+// A ShardedSet which isn't created by NewShardedSet or NewShardedSetN is initialized as NewShardedSet does
+func (s *ShardedSet[T]) load(vals []T) error {
+	if s.shards == nil {
+		*s = *NewShardedSet[T]()
+	}
+	s.lockAll()
+	defer s.unlockAll()
+
+	for i := range s.shards {
+		s.shards[i].data = make(map[T]struct{})
+	}
+	for _, v := range vals {
+		s.shardOf(v).data[v] = struct{}{}
+	}
+	return nil
+}
+
+// MarshalJSON encodes ShardedSet as a JSON array
+func (s *ShardedSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s.ToList())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *ShardedSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes ShardedSet as a JSON array
+func (s *ShardedSet[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *ShardedSet[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes ShardedSet by gob
+func (s *ShardedSet[T]) GobEncode() ([]byte, error) {
+	return gobEncode(s.ToList())
+}
+
+// GobDecode replaces elements with decoded ones
+func (s *ShardedSet[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// load replaces all elements with vals
+func (s *UnsafeSet[T]) load(vals []T) error {
+	s.data = make(map[T]struct{}, len(vals))
+	s.Add(vals...)
+	return nil
+}
+
+// MarshalJSON encodes UnsafeSet as a JSON array
+func (s *UnsafeSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s.ToList())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *UnsafeSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes UnsafeSet as a JSON array
+func (s *UnsafeSet[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *UnsafeSet[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes UnsafeSet by gob
+func (s *UnsafeSet[T]) GobEncode() ([]byte, error) {
+	return gobEncode(s.ToList())
+}
+
+// GobDecode replaces elements with decoded ones
+func (s *UnsafeSet[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// load replaces all elements with vals, a zero UnsafeSortedSet orders elements as a zero SortedSet does
+func (s *UnsafeSortedSet[T]) load(vals []T) error {
+	if s.list == nil {
+		compare := defaultCompare[T]()
+		if compare == nil {
+			return errNilSortedSet
+		}
+		s.list = newSkipList[T](compare)
+	}
+	s.list.clear()
+	s.Add(vals...)
+	return nil
+}
+
+// MarshalJSON encodes UnsafeSortedSet as a JSON array in asc order
+func (s *UnsafeSortedSet[T]) MarshalJSON() ([]byte, error) {
+	if s.list == nil {
+		return marshalJSON[T](nil)
+	}
+	return marshalJSON(s.ToList())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped.
+// A zero UnsafeSortedSet orders elements as a zero SortedSet does
+func (s *UnsafeSortedSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes UnsafeSortedSet as a JSON array
+func (s *UnsafeSortedSet[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *UnsafeSortedSet[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes UnsafeSortedSet by gob
+func (s *UnsafeSortedSet[T]) GobEncode() ([]byte, error) {
+	if s.list == nil {
+		return gobEncode[T](nil)
+	}
+	return gobEncode(s.ToList())
+}
+
+// GobDecode replaces elements with decoded ones.
+// A zero UnsafeSortedSet orders elements as a zero SortedSet does
+func (s *UnsafeSortedSet[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// list returns elements of the wrapped set, a zero SyncSet has no element
+func (s *SyncSet[T]) list() []T {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if s.s == nil {
+		return nil
+	}
+	return s.s.ToList()
+}
+
+// load replaces all elements of the wrapped set with vals, a zero SyncSet wraps a new UnsafeSet
+func (s *SyncSet[T]) load(vals []T) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.s == nil {
+		s.s = NewUnsafeSet[T]()
+	}
+	if l, ok := s.s.(loader[T]); ok {
+		return l.load(vals)
+	}
+	s.s.Clear()
+	s.s.Add(vals...)
+	return nil
+}
+
+// MarshalJSON encodes SyncSet as a JSON array in the order of the wrapped set
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s.list())
+}
+
+// UnmarshalJSON replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *SyncSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	vals, err := unmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
+
+// MarshalText encodes SyncSet as a JSON array
+func (s *SyncSet[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText replaces elements with a decoded JSON array, duplicate elements are dropped
+func (s *SyncSet[T]) UnmarshalText(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// GobEncode encodes SyncSet by gob
+func (s *SyncSet[T]) GobEncode() ([]byte, error) {
+	return gobEncode(s.list())
+}
+
+// GobDecode replaces elements with decoded ones
+func (s *SyncSet[T]) GobDecode(data []byte) error {
+	vals, err := gobDecode[T](data)
+	if err != nil {
+		return err
+	}
+	return s.load(vals)
+}
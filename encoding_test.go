@@ -0,0 +1,205 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	cmp "github.com/visforest/goset/v2/compare"
+)
+
+type pair[K comparable, V comparable] struct {
+	Key K
+	Val V
+}
+
+type payload struct {
+	Tags   *Set[string]
+	Pairs  *FifoSet[pair[string, int]]
+	Stack  *FiloSet[[2]int]
+	Scores *SortedSet[pair[int, string]]
+}
+
+func comparePair(a, b pair[int, string]) int {
+	if c := cmp.Compare(a.Key, b.Key); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Val, b.Val)
+}
+
+func newPayload() payload {
+	return payload{
+		Tags:   NewSet("a", "b"),
+		Pairs:  NewFifoSet(pair[string, int]{"x", 2}, pair[string, int]{"a", 1}),
+		Stack:  NewFiloSet([2]int{1, 2}, [2]int{3, 4}, [2]int{5, 6}),
+		Scores: NewSortedSetFunc(comparePair, pair[int, string]{2, "b"}, pair[int, string]{1, "a"}),
+	}
+}
+
+func checkPayload(t *testing.T, name string, got, want payload) {
+	t.Helper()
+	if !got.Tags.Equals(want.Tags) {
+		t.Fatalf("%s got unexpected Tags %v", name, got.Tags.ToList())
+	}
+	if !reflect.DeepEqual(got.Pairs.ToList(), want.Pairs.ToList()) {
+		t.Fatalf("%s got unexpected Pairs %v", name, got.Pairs.ToList())
+	}
+	if !reflect.DeepEqual(got.Stack.ToList(), want.Stack.ToList()) {
+		t.Fatalf("%s got unexpected Stack %v", name, got.Stack.ToList())
+	}
+	if !reflect.DeepEqual(got.Scores.ToList(), want.Scores.ToList()) {
+		t.Fatalf("%s got unexpected Scores %v", name, got.Scores.ToList())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	want := newPayload()
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal() got unexpected error %v", err)
+	}
+
+	// SortedSet has to be created before decoding to know how to order elements
+	got := payload{Scores: NewSortedSetFunc(comparePair)}
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() got unexpected error %v", err)
+	}
+	checkPayload(t, "json.Unmarshal()", got, want)
+
+	if data, _ = json.Marshal(NewFiloSet(1, 2, 3)); string(data) != "[3,2,1]" {
+		t.Fatalf("json.Marshal(FiloSet) got unexpected %s", data)
+	}
+	if data, _ = json.Marshal(NewFifoSet[int]()); string(data) != "[]" {
+		t.Fatalf("json.Marshal(empty FifoSet) got unexpected %s", data)
+	}
+
+	var f FifoSet[int]
+	if err = json.Unmarshal([]byte("[3,1,3,2,1]"), &f); err != nil || !reflect.DeepEqual(f.ToList(), []int{3, 1, 2}) {
+		t.Fatalf("json.Unmarshal(FifoSet) got unexpected %v %v", f.ToList(), err)
+	}
+	var l FiloSet[int]
+	if err = json.Unmarshal([]byte("[3,1,3,2,1]"), &l); err != nil || !reflect.DeepEqual(l.ToList(), []int{3, 1, 2}) {
+		t.Fatalf("json.Unmarshal(FiloSet) got unexpected %v %v", l.ToList(), err)
+	}
+	var s Set[int]
+	if err = json.Unmarshal([]byte("[3,1,3,2,1]"), &s); err != nil || !s.Equals(NewSet(1, 2, 3)) {
+		t.Fatalf("json.Unmarshal(Set) got unexpected %v %v", s.ToList(), err)
+	}
+	var z SortedSet[int]
+	if err = json.Unmarshal([]byte("[3,1,2]"), &z); err != nil || !reflect.DeepEqual(z.ToList(), []int{1, 2, 3}) {
+		t.Fatalf("json.Unmarshal(zero SortedSet) got unexpected %v %v", z.ToList(), err)
+	}
+	z.Add(0)
+	if r := z.ToList(); !reflect.DeepEqual(r, []int{0, 1, 2, 3}) {
+		t.Fatalf("z.Add(0) after decoding got unexpected %v", r)
+	}
+	type point struct{ X, Y int }
+	var zp SortedSet[point]
+	if err = json.Unmarshal([]byte(`[{"X":1,"Y":2}]`), &zp); err != errNilSortedSet {
+		t.Fatalf("json.Unmarshal(zero SortedSet of structs) got unexpected error %v", err)
+	}
+
+	// sets are usually decoded as fields of API structs
+	type name string
+	var req struct {
+		IDs     *SortedSet[int64]
+		Names   SortedSet[name]
+		Scores  *SortedSet[float64]
+		Pending *FifoSet[int]
+		Stack   FiloSet[int]
+	}
+	raw := `{"IDs":[3,-1,2],"Names":["b","a"],"Scores":[0.5,-1.5],"Pending":[2,1,2],"Stack":[3,1,3]}`
+	if err = json.Unmarshal([]byte(raw), &req); err != nil {
+		t.Fatalf("json.Unmarshal(struct) got unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(req.IDs.ToList(), []int64{-1, 2, 3}) || !reflect.DeepEqual(req.Names.ToList(), []name{"a", "b"}) ||
+		!reflect.DeepEqual(req.Scores.ToList(), []float64{-1.5, 0.5}) {
+		t.Fatalf("json.Unmarshal(struct) got unexpected %v %v %v", req.IDs.ToList(), req.Names.ToList(), req.Scores.ToList())
+	}
+	if !reflect.DeepEqual(req.Pending.ToList(), []int{2, 1}) || !reflect.DeepEqual(req.Stack.ToList(), []int{3, 1}) {
+		t.Fatalf("json.Unmarshal(struct) got unexpected %v %v", req.Pending.ToList(), req.Stack.ToList())
+	}
+
+	var sh struct {
+		Zero ShardedSet[int]
+		Ptr  *ShardedSet[string]
+	}
+	if err = json.Unmarshal([]byte(`{"Zero":[1,2,1],"Ptr":["a"]}`), &sh); err != nil {
+		t.Fatalf("json.Unmarshal(ShardedSet) got unexpected error %v", err)
+	}
+	if !sh.Zero.Equals(NewSet(1, 2)) || !sh.Ptr.Equals(NewSet("a")) {
+		t.Fatalf("json.Unmarshal(ShardedSet) got unexpected %v %v", sh.Zero.ToList(), sh.Ptr.ToList())
+	}
+	if sh.Zero.Add(3); !sh.Zero.Has(3) {
+		t.Fatalf("sh.Zero.Add(3) after decoding got unexpected %v", sh.Zero.ToList())
+	}
+	if data, err = json.Marshal(NewShardedSet(2)); err != nil || string(data) != "[2]" {
+		t.Fatalf("json.Marshal(ShardedSet) got unexpected %s %v", data, err)
+	}
+
+	var un struct {
+		Set    *UnsafeSet[int]
+		Sorted UnsafeSortedSet[string]
+		Sync   SyncSet[int]
+		Fifo   *SyncSet[int]
+	}
+	un.Fifo = Synchronized[int](NewFifoSet[int]())
+	raw = `{"Set":[2,2],"Sorted":["b","a"],"Sync":[3,3],"Fifo":[2,1,2]}`
+	if err = json.Unmarshal([]byte(raw), &un); err != nil {
+		t.Fatalf("json.Unmarshal(unsafe sets) got unexpected error %v", err)
+	}
+	if !un.Set.Equals(NewSet(2)) || !reflect.DeepEqual(un.Sorted.ToList(), []string{"a", "b"}) || !un.Sync.Equals(NewSet(3)) ||
+		!reflect.DeepEqual(un.Fifo.ToList(), []int{2, 1}) {
+		t.Fatalf("json.Unmarshal(unsafe sets) got unexpected %v %v %v %v", un.Set.ToList(), un.Sorted.ToList(), un.Sync.ToList(), un.Fifo.ToList())
+	}
+	if data, err = json.Marshal(&un); err != nil || string(data) != `{"Set":[2],"Sorted":["a","b"],"Sync":[3],"Fifo":[2,1]}` {
+		t.Fatalf("json.Marshal(unsafe sets) got unexpected %s %v", data, err)
+	}
+	if data, err = json.Marshal(&SyncSet[int]{}); err != nil || string(data) != "[]" {
+		t.Fatalf("json.Marshal(zero SyncSet) got unexpected %s %v", data, err)
+	}
+
+	b := NewBoundedFifoSet[int](2)
+	if err = json.Unmarshal([]byte("[1,2,3]"), b); err != nil || !reflect.DeepEqual(b.ToList(), []int{2, 3}) {
+		t.Fatalf("json.Unmarshal(bounded FifoSet) got unexpected %v %v", b.ToList(), err)
+	}
+
+	text, err := NewSortedSet("b", "a").MarshalText()
+	if err != nil || string(text) != `["a","b"]` {
+		t.Fatalf("MarshalText() got unexpected %s %v", text, err)
+	}
+}
+
+func TestGob(t *testing.T) {
+	want := newPayload()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatalf("gob.Encode() got unexpected error %v", err)
+	}
+
+	got := payload{Scores: NewSortedSetFunc(comparePair)}
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("gob.Decode() got unexpected error %v", err)
+	}
+	checkPayload(t, "gob.Decode()", got, want)
+
+	data, err := NewShardedSet(1, 2).GobEncode()
+	if err != nil {
+		t.Fatalf("ShardedSet.GobEncode() got unexpected error %v", err)
+	}
+	var sh ShardedSet[int]
+	if err = sh.GobDecode(data); err != nil || !sh.Equals(NewSet(1, 2)) {
+		t.Fatalf("ShardedSet.GobDecode() got unexpected %v %v", sh.ToList(), err)
+	}
+
+	data, err = Synchronized[int](NewUnsafeSortedSet(2, 1)).GobEncode()
+	if err != nil {
+		t.Fatalf("SyncSet.GobEncode() got unexpected error %v", err)
+	}
+	var z UnsafeSortedSet[int]
+	if err = z.GobDecode(data); err != nil || !reflect.DeepEqual(z.ToList(), []int{1, 2}) {
+		t.Fatalf("UnsafeSortedSet.GobDecode() got unexpected %v %v", z.ToList(), err)
+	}
+}
@@ -0,0 +1,95 @@
+package goset
+
+import "testing"
+
+func TestLinearSetEquals(t *testing.T) {
+	a, b := NewFifoSet(1, 2, 3), NewFifoSet(1, 2, 3)
+	if !a.Equals(b) || !a.EqualsOrdered(b) {
+		t.Fatalf("FifoSets with the same elements got unexpected Equals %v, EqualsOrdered %v", a.Equals(b), a.EqualsOrdered(b))
+	}
+	c := NewFifoSet(3, 1, 2)
+	if !a.Equals(c) || a.EqualsOrdered(c) {
+		t.Fatalf("FifoSets in different orders got unexpected Equals %v, EqualsOrdered %v", a.Equals(c), a.EqualsOrdered(c))
+	}
+	if d := NewFifoSet(1, 2); a.Equals(d) || a.EqualsOrdered(d) || d.EqualsOrdered(a) {
+		t.Fatalf("FifoSets of different lengths got unexpected result")
+	}
+	if a.Equals(nil) || a.EqualsOrdered(nil) {
+		t.Fatalf("FifoSet compared with nil got unexpected result")
+	}
+	if !a.EqualsOrdered(a) {
+		t.Fatalf("a.EqualsOrdered(a) got unexpected false")
+	}
+
+	// elements of FiloSet are in reverse order of adding
+	filo := NewFiloSet(3, 2, 1)
+	if !a.Equals(filo) || !a.EqualsOrdered(filo) || !filo.EqualsOrdered(a) {
+		t.Fatalf("FifoSet and FiloSet in the same order got unexpected result")
+	}
+	if filo2 := NewFiloSet(1, 2, 3); !filo.Equals(filo2) || filo.EqualsOrdered(filo2) {
+		t.Fatalf("FiloSets in different orders got unexpected result")
+	}
+
+	// sets without order are compared by ToList
+	if !a.Equals(NewSet(3, 2, 1)) || !a.EqualsOrdered(NewSortedSet(3, 2, 1)) || a.EqualsOrdered(NewSortedSet(4, 2, 1)) {
+		t.Fatalf("FifoSet compared with other sets got unexpected result")
+	}
+
+	a.Delete(2)
+	a.Add(2)
+	if !a.Equals(b) || a.EqualsOrdered(b) || !a.EqualsOrdered(NewFifoSet(1, 3, 2)) {
+		t.Fatalf("FifoSet after moving an element got unexpected %v", a.ToList())
+	}
+}
+
+func TestSortedSetEqualsOrdered(t *testing.T) {
+	a, b := NewSortedSet(3, 1, 2), NewSortedSet(1, 2, 3)
+	if !a.Equals(b) || !a.EqualsOrdered(b) || !a.EqualsOrdered(a) {
+		t.Fatalf("SortedSets with the same elements got unexpected result")
+	}
+	desc := NewSortedSetFunc(func(a, b int) int { return b - a }, 1, 2, 3)
+	if !a.Equals(desc) || a.EqualsOrdered(desc) {
+		t.Fatalf("SortedSets in different orders got unexpected Equals %v, EqualsOrdered %v", a.Equals(desc), a.EqualsOrdered(desc))
+	}
+	if !a.EqualsOrdered(NewFifoSet(1, 2, 3)) || a.EqualsOrdered(NewFifoSet(1, 3, 2)) || a.EqualsOrdered(NewSortedSet(1, 2)) {
+		t.Fatalf("SortedSet compared with FifoSet got unexpected result")
+	}
+	if a.EqualsOrdered(nil) {
+		t.Fatalf("SortedSet compared with nil got unexpected result")
+	}
+
+	u := NewUnsafeSortedSet(2, 1)
+	if !u.EqualsOrdered(NewUnsafeSortedSet(1, 2)) || u.EqualsOrdered(NewUnsafeSortedSet(1, 2, 3)) || !u.EqualsOrdered(NewFiloSet(2, 1)) {
+		t.Fatalf("UnsafeSortedSet.EqualsOrdered() got unexpected result")
+	}
+}
+
+func TestSetEquals(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet[int]()
+	for i := 10; i > 0; i-- {
+		b.Add(i)
+	}
+	b.Delete(4, 5, 6, 7, 8, 9, 10)
+	if !a.Equals(b) || !b.Equals(a) {
+		t.Fatalf("Sets with the same members got unexpected false")
+	}
+	if b.Add(4); a.Equals(b) || b.Equals(a) {
+		t.Fatalf("Sets with different members got unexpected true")
+	}
+	if u := NewUnsafeSet(1, 2, 3); !u.Equals(NewUnsafeSet(3, 2, 1)) || u.Equals(NewUnsafeSet(1, 2, 4)) || !u.IsSub(NewUnsafeSet(1, 2, 3, 4)) {
+		t.Fatalf("UnsafeSet.Equals() got unexpected result")
+	}
+}
+
+func BenchmarkSetEquals(b *testing.B) {
+	vals := make([]int, 1000)
+	for i := range vals {
+		vals[i] = i
+	}
+	s, t := NewSet(vals...), NewSet(vals...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Equals(t)
+	}
+}
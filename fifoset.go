@@ -1,5 +1,10 @@
 package goset
 
+import (
+	"context"
+	"fmt"
+)
+
 // FifoSet is a set whose elements are stored by fifo
 type FifoSet[T comparable] struct {
 	*linearSet[T]
@@ -9,35 +14,178 @@ func NewFifoSet[T comparable](vals ...T) *FifoSet[T] {
 	return &FifoSet[T]{newLinearSet[T](addFifo[T], vals...)}
 }
 
+// NewBoundedFifoSet creates a new FifoSet holding at most capacity elements,
+// the first in element is evicted when adding an element would exceed the capacity.
+// Sets returned by Copy and set operations are unbounded.
+// It panics if capacity isn't positive
+//
+// for example:
+// var s=NewBoundedFifoSet(2, 1, 2, 3)
+// s.ToList() returns [2,3]
+func NewBoundedFifoSet[T comparable](capacity int, vals ...T) *FifoSet[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("goset: capacity %d isn't positive", capacity))
+	}
+	s := NewFifoSet[T]()
+	s.capacity = capacity
+	s.Add(vals...)
+	return s
+}
+
+// Add adds elements to the back, the first in elements are evicted if the capacity is exceeded
 func (s *FifoSet[T]) Add(vals ...T) {
-	addFifo(s.linearSet, vals...)
+	s.bounded(func() {
+		pushBackAll(s.linearSet, vals)
+	})
+}
+
+// OnEvict sets the callback called with every evicted element after the lock is released,
+// so the callback can access the FifoSet
+func (s *FifoSet[T]) OnEvict(fn func(v T)) {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	s.onEvict = fn
+}
+
+// Evictions returns the number of elements evicted since FifoSet was created
+func (s *FifoSet[T]) Evictions() uint64 {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.evictions
+}
+
+// Capacity returns the max number of elements, 0 means unbounded
+func (s *FifoSet[T]) Capacity() int {
+	return s.capacity
 }
 
 // Copy returns a deep copy of itself
 func (s *FifoSet[T]) Copy() *FifoSet[T] {
-	return &FifoSet[T]{s.linearSet.copy(addFifo[T])}
+	return &FifoSet[T]{s.linearSet.copy()}
+}
+
+// Equals returns whether it has the same members with set t, the order is ignored
+func (s *FifoSet[T]) Equals(t ReadableSet[T]) bool {
+	return s.linearSet.Equals(t)
+}
+
+// EqualsOrdered returns whether it has the same elements with set t in the same order,
+// elements of t are in the order of its ToList
+func (s *FifoSet[T]) EqualsOrdered(t ReadableSet[T]) bool {
+	return s.linearSet.EqualsOrdered(t)
 }
 
-func (s *FifoSet[T]) Equals(t *FifoSet[T]) bool {
-	return s.linearSet.Equals(t.linearSet)
+func (s *FifoSet[T]) IsSub(t ReadableSet[T]) bool {
+	return s.linearSet.IsSub(t)
 }
 
-func (s *FifoSet[T]) IsSub(t *FifoSet[T]) bool {
-	return s.linearSet.IsSub(t.linearSet)
+func (s *FifoSet[T]) Union(t ReadableSet[T]) *FifoSet[T] {
+	return &FifoSet[T]{s.linearSet.union(t, pushBackAll[T])}
 }
 
-func (s *FifoSet[T]) Union(t *FifoSet[T]) *FifoSet[T] {
-	return &FifoSet[T]{s.linearSet.union(t.linearSet, addFifo[T])}
+func (s *FifoSet[T]) Subtract(t ReadableSet[T]) *FifoSet[T] {
+	return &FifoSet[T]{s.linearSet.subtract(t)}
 }
 
-func (s *FifoSet[T]) Subtract(t *FifoSet[T]) *FifoSet[T] {
-	return &FifoSet[T]{s.linearSet.subtract(t.linearSet, addFifo[T])}
+func (s *FifoSet[T]) Intersect(t ReadableSet[T]) *FifoSet[T] {
+	return &FifoSet[T]{s.linearSet.intersect(t)}
 }
 
-func (s *FifoSet[T]) Intersect(t *FifoSet[T]) *FifoSet[T] {
-	return &FifoSet[T]{s.linearSet.intersect(t.linearSet, addFifo[T])}
+func (s *FifoSet[T]) Complement(t ReadableSet[T]) *FifoSet[T] {
+	return &FifoSet[T]{s.linearSet.complement(t, pushBackAll[T])}
 }
 
-func (s *FifoSet[T]) Complement(t *FifoSet[T]) *FifoSet[T] {
-	return &FifoSet[T]{s.linearSet.complement(t.linearSet, addFifo[T])}
+// UnionWith adds elements of set t, and returns the number of added elements
+func (s *FifoSet[T]) UnionWith(t ReadableSet[T]) int {
+	return s.linearSet.unionWith(t, pushBackAll[T])
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *FifoSet[T]) IntersectWith(t ReadableSet[T]) int {
+	return s.linearSet.intersectWith(t)
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *FifoSet[T]) SubtractWith(t ReadableSet[T]) int {
+	return s.linearSet.subtractWith(t)
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *FifoSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	return s.linearSet.symmetricDifferenceWith(t, pushBackAll[T])
+}
+
+// Pop removes and returns the first in element, ok is false if FifoSet is empty
+func (s *FifoSet[T]) Pop() (v T, ok bool) {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.popFront()
+}
+
+// Peek returns the first in element without removing it, ok is false if FifoSet is empty
+func (s *FifoSet[T]) Peek() (v T, ok bool) {
+	return s.peekFront()
+}
+
+// PopN removes and returns at most n elements from the first in one
+func (s *FifoSet[T]) PopN(n int) []T {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.popFrontN(n)
+}
+
+// PushFront puts elements before the first in element, so that vals[0] will be popped first.
+// Elements already existing are left unchanged.
+// If the capacity is exceeded, elements at the front are evicted, including the ones just put
+func (s *FifoSet[T]) PushFront(vals ...T) {
+	s.bounded(func() {
+		pushFrontAll(s.linearSet, vals)
+	})
+}
+
+// MoveToBack moves v to the back, as if it's added again, and returns false if v doesn't exist
+func (s *FifoSet[T]) MoveToBack(v T) bool {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.moveToBack(v)
+}
+
+// PopWait removes and returns the first in element, it blocks until an element is added if FifoSet is empty.
+// It returns ctx.Err() if ctx is done before any element is available
+func (s *FifoSet[T]) PopWait(ctx context.Context) (v T, err error) {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	if v, ok := s.popFront(); ok {
+		return v, nil
+	}
+
+	// wake up waiters when ctx is done, since added can't be waited with ctx
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.m.Lock()
+			s.added.Broadcast()
+			s.m.Unlock()
+		case <-stop:
+		}
+	}()
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return v, err
+		}
+		s.added.Wait()
+		if v, ok := s.popFront(); ok {
+			return v, nil
+		}
+	}
 }
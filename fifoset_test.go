@@ -0,0 +1,160 @@
+package goset
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFifoSetQueue(t *testing.T) {
+	s := NewFifoSet(1, 2, 3)
+	if v, ok := s.Peek(); !ok || v != 1 || s.Length() != 3 {
+		t.Fatalf("s.Peek() got unexpected %v %v", v, ok)
+	}
+	if v, ok := s.Pop(); !ok || v != 1 || !reflect.DeepEqual(s.ToList(), []int{2, 3}) {
+		t.Fatalf("s.Pop() got unexpected %v %v, %v", v, ok, s.ToList())
+	}
+
+	s.PushFront(7, 8, 3)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{7, 8, 2, 3}) {
+		t.Fatalf("s.PushFront(7, 8, 3) got unexpected %v", r)
+	}
+	if !s.MoveToBack(7) || s.MoveToBack(100) {
+		t.Fatalf("s.MoveToBack() got unexpected result")
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{8, 2, 3, 7}) {
+		t.Fatalf("s.MoveToBack(7) got unexpected %v", r)
+	}
+	if v, _ := s.Peek(); v != 8 {
+		t.Fatalf("s.Peek() after MoveToBack got unexpected %v", v)
+	}
+
+	if r := s.PopN(3); !reflect.DeepEqual(r, []int{8, 2, 3}) || !reflect.DeepEqual(s.ToList(), []int{7}) {
+		t.Fatalf("s.PopN(3) got unexpected %v, %v", r, s.ToList())
+	}
+	if r := s.PopN(5); !reflect.DeepEqual(r, []int{7}) || s.Length() != 0 {
+		t.Fatalf("s.PopN(5) got unexpected %v, %v", r, s.ToList())
+	}
+	if r := s.PopN(1); r != nil {
+		t.Fatalf("s.PopN(1) on empty set got unexpected %v", r)
+	}
+	if v, ok := s.Pop(); ok {
+		t.Fatalf("s.Pop() on empty set got unexpected %v", v)
+	}
+	if v, ok := s.Peek(); ok {
+		t.Fatalf("s.Peek() on empty set got unexpected %v", v)
+	}
+
+	// popped elements can be added again
+	s.Add(1, 2)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1, 2}) {
+		t.Fatalf("s.Add(1, 2) after popping got unexpected %v", r)
+	}
+}
+
+func TestFifoSetPopWait(t *testing.T) {
+	s := NewFifoSet(1)
+	if v, err := s.PopWait(context.Background()); err != nil || v != 1 {
+		t.Fatalf("s.PopWait() got unexpected %v %v", v, err)
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	got := make(chan int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := s.PopWait(context.Background())
+			if err != nil {
+				t.Errorf("s.PopWait() got unexpected error %v", err)
+				return
+			}
+			got <- v
+		}()
+	}
+	for i := 0; i < n; i++ {
+		s.Add(i)
+	}
+	wg.Wait()
+	close(got)
+	seen := NewSet[int]()
+	for v := range got {
+		seen.Add(v)
+	}
+	if seen.Length() != n || s.Length() != 0 {
+		t.Fatalf("s.PopWait() by %d goroutines got unexpected %v, left %v", n, seen.ToList(), s.ToList())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if v, err := s.PopWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("s.PopWait() on empty set got unexpected %v %v", v, err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Add(9)
+	if v, err := s.PopWait(canceled); err != nil || v != 9 {
+		t.Fatalf("s.PopWait() with available element got unexpected %v %v", v, err)
+	}
+}
+
+func TestBoundedFifoSet(t *testing.T) {
+	s := NewBoundedFifoSet(3, 1, 2, 3, 4)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{2, 3, 4}) || s.Evictions() != 1 || s.Capacity() != 3 {
+		t.Fatalf("NewBoundedFifoSet(3, 1, 2, 3, 4) got unexpected %v, evictions %d", r, s.Evictions())
+	}
+
+	var evicted []int
+	s.OnEvict(func(v int) {
+		evicted = append(evicted, v)
+		// the lock is released before calling the callback
+		s.Has(v)
+	})
+	s.Add(3, 5, 6)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{4, 5, 6}) || !reflect.DeepEqual(evicted, []int{2, 3}) {
+		t.Fatalf("s.Add(3, 5, 6) got unexpected %v, evicted %v", r, evicted)
+	}
+	if s.Evictions() != 3 {
+		t.Fatalf("s.Evictions() got unexpected %d", s.Evictions())
+	}
+
+	// duplicate elements don't evict anything
+	s.Add(4, 5)
+	if s.Length() != 3 || s.Evictions() != 3 {
+		t.Fatalf("s.Add(4, 5) got unexpected %v, evictions %d", s.ToList(), s.Evictions())
+	}
+
+	if n := s.UnionWith(NewFifoSet(7, 8)); n != 2 || !reflect.DeepEqual(s.ToList(), []int{6, 7, 8}) {
+		t.Fatalf("s.UnionWith() got unexpected %d, %v", n, s.ToList())
+	}
+	s.PushFront(9)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{6, 7, 8}) || s.Evictions() != 6 {
+		t.Fatalf("s.PushFront(9) got unexpected %v, evictions %d", r, s.Evictions())
+	}
+	if !reflect.DeepEqual(evicted, []int{2, 3, 4, 5, 9}) {
+		t.Fatalf("evicted elements got unexpected %v", evicted)
+	}
+
+	// popping makes room without evictions
+	s.Pop()
+	s.Add(10)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{7, 8, 10}) || s.Evictions() != 6 {
+		t.Fatalf("s.Add(10) after s.Pop() got unexpected %v, evictions %d", r, s.Evictions())
+	}
+
+	if c := s.Copy(); c.Capacity() != 0 || NewFifoSet(1).Capacity() != 0 {
+		t.Fatalf("s.Copy() got unexpected capacity %d", c.Capacity())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("NewBoundedFifoSet(0) should panic")
+		}
+	}()
+	NewBoundedFifoSet[int](0)
+}
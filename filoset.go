@@ -1,6 +1,7 @@
 package goset
 
-// FiloSet is a set that first in, last out
+// FiloSet is a set that first in, last out.
+// ToList lists elements from the top of the stack, which is the last in element
 type FiloSet[T comparable] struct {
 	*linearSet[T]
 }
@@ -9,34 +10,113 @@ func NewFiloSet[T comparable](vals ...T) *FiloSet[T] {
 	return &FiloSet[T]{newLinearSet[T](addFilo[T], vals...)}
 }
 
+// Add pushes elements onto the top in order, so that the last one becomes the top.
+// Elements already existing are left unchanged
 func (s *FiloSet[T]) Add(vals ...T) {
 	addFilo(s.linearSet, vals...)
 }
 
+// Push is the same as Add
+func (s *FiloSet[T]) Push(vals ...T) {
+	addFilo(s.linearSet, vals...)
+}
+
+// Pop removes and returns the top element, ok is false if FiloSet is empty
+func (s *FiloSet[T]) Pop() (v T, ok bool) {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.popFront()
+}
+
+// Peek returns the top element without removing it, ok is false if FiloSet is empty
+func (s *FiloSet[T]) Peek() (v T, ok bool) {
+	return s.peekFront()
+}
+
+// PopN removes and returns at most n elements from the top
+func (s *FiloSet[T]) PopN(n int) []T {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.popFrontN(n)
+}
+
+// MoveToTop moves v to the top, as if it's pushed again, and returns false if v doesn't exist
+func (s *FiloSet[T]) MoveToTop(v T) bool {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	if !s.remove(v) {
+		return false
+	}
+	s.pushFront(v)
+	return true
+}
+
+// Copy returns a deep copy of itself in the same order
 func (s *FiloSet[T]) Copy() *FiloSet[T] {
-	return &FiloSet[T]{s.linearSet.copy(addFifo[T])}
+	return &FiloSet[T]{s.linearSet.copy()}
+}
+
+// Equals returns whether it has the same members with set t, the order is ignored
+func (s *FiloSet[T]) Equals(t ReadableSet[T]) bool {
+	return s.linearSet.Equals(t)
+}
+
+// EqualsOrdered returns whether it has the same elements with set t in the same order,
+// elements of t are in the order of its ToList
+func (s *FiloSet[T]) EqualsOrdered(t ReadableSet[T]) bool {
+	return s.linearSet.EqualsOrdered(t)
+}
+
+func (s *FiloSet[T]) IsSub(t ReadableSet[T]) bool {
+	return s.linearSet.IsSub(t)
+}
+
+// Union returns a new FiloSet with elements of t which don't exist in s put on the top,
+// in the order of t's ToList
+//
+// for example:
+// var a=NewFiloSet(1,2,3)
+// a.Union(NewFiloSet(4,5,3)) returns [5 4 3 2 1] from the top
+func (s *FiloSet[T]) Union(t ReadableSet[T]) *FiloSet[T] {
+	return &FiloSet[T]{s.linearSet.union(t, pushFrontAll[T])}
+}
+
+// Subtract returns a new FiloSet whose elements exist in itself but don't exist in set t, in the same order
+func (s *FiloSet[T]) Subtract(t ReadableSet[T]) *FiloSet[T] {
+	return &FiloSet[T]{s.linearSet.subtract(t)}
 }
 
-func (s *FiloSet[T]) Equals(t *FiloSet[T]) bool {
-	return s.linearSet.Equals(t.linearSet)
+// Intersect returns a new FiloSet whose elements exist in both sets, in the same order
+func (s *FiloSet[T]) Intersect(t ReadableSet[T]) *FiloSet[T] {
+	return &FiloSet[T]{s.linearSet.intersect(t)}
 }
 
-func (s *FiloSet[T]) IsSub(t *FiloSet[T]) bool {
-	return s.linearSet.IsSub(t.linearSet)
+// Complement returns a new FiloSet whose elements only exist in one set,
+// elements of t are put on the top as Union does
+func (s *FiloSet[T]) Complement(t ReadableSet[T]) *FiloSet[T] {
+	return &FiloSet[T]{s.linearSet.complement(t, pushFrontAll[T])}
 }
 
-func (s *FiloSet[T]) Union(t *FiloSet[T]) *FiloSet[T] {
-	return &FiloSet[T]{s.linearSet.union(t.linearSet, addFifo[T])}
+// UnionWith puts elements of set t on the top as Union does, and returns the number of added elements
+func (s *FiloSet[T]) UnionWith(t ReadableSet[T]) int {
+	return s.linearSet.unionWith(t, pushFrontAll[T])
 }
 
-func (s *FiloSet[T]) Subtract(t *FiloSet[T]) *FiloSet[T] {
-	return &FiloSet[T]{s.linearSet.subtract(t.linearSet, addFifo[T])}
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *FiloSet[T]) IntersectWith(t ReadableSet[T]) int {
+	return s.linearSet.intersectWith(t)
 }
 
-func (s *FiloSet[T]) Intersect(t *FiloSet[T]) *FiloSet[T] {
-	return &FiloSet[T]{s.linearSet.intersect(t.linearSet, addFifo[T])}
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *FiloSet[T]) SubtractWith(t ReadableSet[T]) int {
+	return s.linearSet.subtractWith(t)
 }
 
-func (s *FiloSet[T]) Complement(t *FiloSet[T]) *FiloSet[T] {
-	return &FiloSet[T]{s.linearSet.complement(t.linearSet, addFifo[T])}
+// SymmetricDifferenceWith deletes elements which exist in set t and puts the others of t on the top as Union does,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *FiloSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	return s.linearSet.symmetricDifferenceWith(t, pushFrontAll[T])
 }
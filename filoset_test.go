@@ -0,0 +1,98 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFiloSetStack(t *testing.T) {
+	s := NewFiloSet(1, 2)
+	s.Push(3, 1)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{3, 2, 1}) {
+		t.Fatalf("s.Push(3, 1) got unexpected %v", r)
+	}
+	if v, ok := s.Peek(); !ok || v != 3 || s.Length() != 3 {
+		t.Fatalf("s.Peek() got unexpected %v %v", v, ok)
+	}
+	if v, ok := s.Pop(); !ok || v != 3 || !reflect.DeepEqual(s.ToList(), []int{2, 1}) {
+		t.Fatalf("s.Pop() got unexpected %v %v, %v", v, ok, s.ToList())
+	}
+
+	s.Push(4, 5)
+	if !s.MoveToTop(1) || s.MoveToTop(100) {
+		t.Fatalf("s.MoveToTop() got unexpected result")
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1, 5, 4, 2}) {
+		t.Fatalf("s.MoveToTop(1) got unexpected %v", r)
+	}
+
+	if r := s.PopN(3); !reflect.DeepEqual(r, []int{1, 5, 4}) || !reflect.DeepEqual(s.ToList(), []int{2}) {
+		t.Fatalf("s.PopN(3) got unexpected %v, %v", r, s.ToList())
+	}
+	if r := s.PopN(2); !reflect.DeepEqual(r, []int{2}) || s.Length() != 0 {
+		t.Fatalf("s.PopN(2) got unexpected %v, %v", r, s.ToList())
+	}
+	if v, ok := s.Pop(); ok {
+		t.Fatalf("s.Pop() on empty set got unexpected %v", v)
+	}
+	if v, ok := s.Peek(); ok {
+		t.Fatalf("s.Peek() on empty set got unexpected %v", v)
+	}
+}
+
+func TestFiloSetOrder(t *testing.T) {
+	// [3 2 1] from the top
+	s := NewFiloSet(1, 2, 3)
+	check := func(name string, r *FiloSet[int], want []int) {
+		t.Helper()
+		if got := r.ToList(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s got unexpected %v, should be %v", name, got, want)
+		}
+	}
+
+	check("s.Copy()", s.Copy(), []int{3, 2, 1})
+	check("s.Union(FiloSet)", s.Union(NewFiloSet(4, 5, 3)), []int{5, 4, 3, 2, 1})
+	check("s.Union(FifoSet)", s.Union(NewFifoSet(4, 5, 3)), []int{4, 5, 3, 2, 1})
+	check("s.Union(nil)", s.Union(nil), []int{3, 2, 1})
+	check("s.Union(s)", s.Union(s), []int{3, 2, 1})
+	check("s.Intersect()", s.Intersect(NewFifoSet(1, 3, 4)), []int{3, 1})
+	check("s.Intersect(small)", s.Intersect(NewSet(1, 3)), []int{3, 1})
+	check("s.Subtract()", s.Subtract(NewSet(2)), []int{3, 1})
+	check("s.Complement()", s.Complement(NewFiloSet(2, 4, 5)), []int{5, 4, 3, 1})
+	check("s.Complement(s)", s.Complement(s), nil)
+
+	// popping the copies keeps the stack order
+	c := s.Union(NewFiloSet(4))
+	var popped []int
+	for v, ok := c.Pop(); ok; v, ok = c.Pop() {
+		popped = append(popped, v)
+	}
+	if !reflect.DeepEqual(popped, []int{4, 3, 2, 1}) {
+		t.Fatalf("popping s.Union() got unexpected %v", popped)
+	}
+
+	w := s.Copy()
+	w.UnionWith(NewFiloSet(4, 5))
+	check("w.UnionWith()", w, []int{5, 4, 3, 2, 1})
+	w.SymmetricDifferenceWith(NewFifoSet(6, 7, 5))
+	check("w.SymmetricDifferenceWith()", w, []int{6, 7, 4, 3, 2, 1})
+	w.IntersectWith(NewSet(7, 3, 1))
+	check("w.IntersectWith()", w, []int{7, 3, 1})
+	w.SubtractWith(NewSet(3))
+	check("w.SubtractWith()", w, []int{7, 1})
+}
+
+func TestFifoSetOrder(t *testing.T) {
+	s := NewFifoSet(1, 2, 3)
+	check := func(name string, r *FifoSet[int], want []int) {
+		t.Helper()
+		if got := r.ToList(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s got unexpected %v, should be %v", name, got, want)
+		}
+	}
+	check("s.Copy()", s.Copy(), []int{1, 2, 3})
+	check("s.Union()", s.Union(NewFifoSet(4, 2, 5)), []int{1, 2, 3, 4, 5})
+	check("s.Intersect(small)", s.Intersect(NewFifoSet(3, 1)), []int{1, 3})
+	check("s.Subtract()", s.Subtract(NewSet(2)), []int{1, 3})
+	check("s.Complement()", s.Complement(NewFifoSet(5, 2, 4)), []int{1, 3, 5, 4})
+}
@@ -0,0 +1,113 @@
+package goset
+
+// filterable is implemented by sets which can be copied and modified, such as Set, FifoSet, FiloSet and SortedSet
+type filterable[T comparable, S any] interface {
+	Interface[T]
+	Copy() S
+}
+
+// Map returns a new Set of f applied to every element of s
+//
+// for example:
+// var a=NewSet(1,-1,2)
+// Map(a, func(v int) int { return v * v }) returns {1,4}
+func Map[T, U comparable](s ReadableSet[T], f func(v T) U) *Set[U] {
+	vals := s.ToList()
+	r := NewUnsafeSet[U]()
+	for _, v := range vals {
+		r.Add(f(v))
+	}
+	return &Set[U]{u: *r}
+}
+
+// Filter returns a new set of the same kind with elements satisfying pred, the order of elements is preserved
+//
+// for example:
+// var a=NewFifoSet(3,1,4,2)
+// Filter(a, func(v int) bool { return v > 1 }) returns FifoSet{3,4,2}
+func Filter[T comparable, S filterable[T, S]](s S, pred func(v T) bool) S {
+	r, _ := partition[T](s, pred, false)
+	return r
+}
+
+// Partition returns two new sets of the same kind, yes contains elements satisfying pred and no contains the others,
+// the order of elements is preserved
+func Partition[T comparable, S filterable[T, S]](s S, pred func(v T) bool) (yes, no S) {
+	return partition[T](s, pred, true)
+}
+
+// partition copies s into yes and no if both is true, then deletes elements from them by pred
+func partition[T comparable, S filterable[T, S]](s S, pred func(v T) bool, both bool) (yes, no S) {
+	yes = s.Copy()
+	var dropYes, dropNo []T
+	for _, v := range yes.ToList() {
+		if pred(v) {
+			dropNo = append(dropNo, v)
+		} else {
+			dropYes = append(dropYes, v)
+		}
+	}
+	if both {
+		no = yes.Copy()
+		no.Delete(dropNo...)
+	}
+	yes.Delete(dropYes...)
+	return yes, no
+}
+
+// Reduce folds elements of s into an accumulator starting from init, elements are visited in the order of ToList
+//
+// for example:
+// var a=NewSet(1,2,3)
+// Reduce(a, 0, func(sum, v int) int { return sum + v }) returns 6
+func Reduce[T comparable, A any](s ReadableSet[T], init A, f func(acc A, v T) A) A {
+	acc := init
+	for _, v := range s.ToList() {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Any returns whether any element of s satisfies pred, it returns false if s is empty
+func Any[T comparable](s ReadableSet[T], pred func(v T) bool) bool {
+	for _, v := range s.ToList() {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns whether all elements of s satisfy pred, it returns true if s is empty
+func All[T comparable](s ReadableSet[T], pred func(v T) bool) bool {
+	for _, v := range s.ToList() {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// None returns whether no element of s satisfies pred, it returns true if s is empty
+func None[T comparable](s ReadableSet[T], pred func(v T) bool) bool {
+	return !Any(s, pred)
+}
+
+// GroupBy groups elements of s into Sets by their keys
+//
+// for example:
+// var a=NewSet(1,2,3,4)
+// GroupBy(a, func(v int) bool { return v%2 == 0 }) returns {false:{1,3}, true:{2,4}}
+func GroupBy[T comparable, K comparable](s ReadableSet[T], key func(v T) K) map[K]*Set[T] {
+	r := make(map[K]*Set[T])
+	for _, v := range s.ToList() {
+		k := key(v)
+		g, ok := r[k]
+		if !ok {
+			g = NewSet[T]()
+			r[k] = g
+		}
+		g.u.Add(v)
+	}
+	return r
+}
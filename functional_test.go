@@ -0,0 +1,94 @@
+package goset
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	s := NewFifoSet(1, -1, 2)
+	if r := Map[int, int](s, func(v int) int { return v * v }); !r.Equals(NewSet(1, 4)) {
+		t.Fatalf("Map() got unexpected %v", r.ToList())
+	}
+	if r := Map[int, string](NewSortedSet(1, 2), strconv.Itoa); !r.Equals(NewSet("1", "2")) {
+		t.Fatalf("Map() got unexpected %v", r.ToList())
+	}
+	if r := Map[int, int](NewSet[int](), func(v int) int { return v }); r.Length() != 0 {
+		t.Fatalf("Map() on empty set got unexpected %v", r.ToList())
+	}
+}
+
+func TestFilter(t *testing.T) {
+	gt1 := func(v int) bool { return v > 1 }
+
+	fifo := NewFifoSet(3, 1, 4, 2)
+	if r := Filter(fifo, gt1); !reflect.DeepEqual(r.ToList(), []int{3, 4, 2}) {
+		t.Fatalf("Filter(FifoSet) got unexpected %v", r.ToList())
+	}
+	if !reflect.DeepEqual(fifo.ToList(), []int{3, 1, 4, 2}) {
+		t.Fatalf("Filter(FifoSet) modified the set %v", fifo.ToList())
+	}
+	if r := Filter(NewFiloSet(3, 1, 4, 2), gt1); !reflect.DeepEqual(r.ToList(), []int{2, 4, 3}) {
+		t.Fatalf("Filter(FiloSet) got unexpected %v", r.ToList())
+	}
+	if r := Filter(NewSortedSet(3, 1, 4, 2), gt1); !reflect.DeepEqual(r.ToList(), []int{2, 3, 4}) {
+		t.Fatalf("Filter(SortedSet) got unexpected %v", r.ToList())
+	}
+	if r := Filter(NewSet(3, 1, 4, 2), gt1); !r.Equals(NewSet(2, 3, 4)) {
+		t.Fatalf("Filter(Set) got unexpected %v", r.ToList())
+	}
+	if r := Filter(NewUnsafeSet(3, 1), gt1); !r.Equals(NewSet(3)) {
+		t.Fatalf("Filter(UnsafeSet) got unexpected %v", r.ToList())
+	}
+}
+
+func TestPartition(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+
+	yes, no := Partition(NewFifoSet(5, 2, 3, 8, 1), even)
+	if !reflect.DeepEqual(yes.ToList(), []int{2, 8}) || !reflect.DeepEqual(no.ToList(), []int{5, 3, 1}) {
+		t.Fatalf("Partition(FifoSet) got unexpected %v and %v", yes.ToList(), no.ToList())
+	}
+	zyes, zno := Partition(NewSortedSet(5, 2, 3, 8, 1), even)
+	if !reflect.DeepEqual(zyes.ToList(), []int{2, 8}) || !reflect.DeepEqual(zno.ToList(), []int{1, 3, 5}) {
+		t.Fatalf("Partition(SortedSet) got unexpected %v and %v", zyes.ToList(), zno.ToList())
+	}
+	syes, sno := Partition(NewShardedSet(5, 2), even)
+	if !syes.Equals(NewSet(2)) || !sno.Equals(NewSet(5)) {
+		t.Fatalf("Partition(ShardedSet) got unexpected %v and %v", syes.ToList(), sno.ToList())
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+	if r := Reduce[int](NewSet(1, 2, 3), 0, sum); r != 6 {
+		t.Fatalf("Reduce() got unexpected %d", r)
+	}
+	concat := func(acc string, v string) string { return acc + v }
+	if r := Reduce[string](NewFiloSet("a", "b", "c"), ">", concat); r != ">cba" {
+		t.Fatalf("Reduce(FiloSet) got unexpected %s", r)
+	}
+}
+
+func TestAnyAllNone(t *testing.T) {
+	neg := func(v int) bool { return v < 0 }
+	s := NewSortedSet(1, -2, 3)
+	if !Any[int](s, neg) || All[int](s, neg) || None[int](s, neg) {
+		t.Fatalf("Any/All/None() got unexpected result")
+	}
+	empty := NewSet[int]()
+	if Any[int](empty, neg) || !All[int](empty, neg) || !None[int](empty, neg) {
+		t.Fatalf("Any/All/None() on empty set got unexpected result")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	r := GroupBy[int, bool](NewFifoSet(1, 2, 3, 4, 5), func(v int) bool { return v%2 == 0 })
+	if len(r) != 2 || !r[true].Equals(NewSet(2, 4)) || !r[false].Equals(NewSet(1, 3, 5)) {
+		t.Fatalf("GroupBy() got unexpected %v", r)
+	}
+	if r := GroupBy[int, int](NewSet[int](), func(v int) int { return v }); len(r) != 0 {
+		t.Fatalf("GroupBy() on empty set got unexpected %v", r)
+	}
+}
@@ -0,0 +1,252 @@
+package goset
+
+import "math/bits"
+
+const (
+	// hamtBits is the number of hash bits consumed by each level of hamt
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+// hamtNode is a node of a hash array mapped trie, which is never modified after being built,
+// so nodes can be shared among versions of ImmutableSet.
+// Each level indexes slots by 5 bits of the hash, bitmap marks which of the 32 slots are present.
+// Values whose hashes are totally equal are stored in a collision node below the last level, whose bitmap is unused
+type hamtNode[T comparable] struct {
+	bitmap uint32
+	// size is the number of values in the subtree
+	size  int
+	slots []hamtSlot[T]
+}
+
+// hamtSlot is either a value or a child node if child isn't nil.
+// A child node always contains more than one value, otherwise it's replaced by the value
+type hamtSlot[T comparable] struct {
+	child *hamtNode[T]
+	hash  uint64
+	val   T
+}
+
+func (s *hamtSlot[T]) size() int {
+	if s.child != nil {
+		return s.child.size
+	}
+	return 1
+}
+
+// index returns the position of bit in slots
+func (n *hamtNode[T]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// bitOf returns the bit of hash h in the node at shift
+func bitOf(h uint64, shift uint) uint32 {
+	return 1 << ((h >> shift) & hamtMask)
+}
+
+// has returns whether v with hash h exists in the subtree
+func (n *hamtNode[T]) has(h uint64, v T, shift uint) bool {
+	for {
+		if shift >= 64 {
+			for _, s := range n.slots {
+				if s.val == v {
+					return true
+				}
+			}
+			return false
+		}
+		bit := bitOf(h, shift)
+		if n.bitmap&bit == 0 {
+			return false
+		}
+		s := &n.slots[n.index(bit)]
+		if s.child == nil {
+			return s.hash == h && s.val == v
+		}
+		n, shift = s.child, shift+hamtBits
+	}
+}
+
+// with returns a new node with v added, which shares unchanged subtrees with n.
+// It returns n itself if v already exists
+func (n *hamtNode[T]) with(h uint64, v T, shift uint) *hamtNode[T] {
+	if shift >= 64 {
+		if n.has(h, v, shift) {
+			return n
+		}
+		r := &hamtNode[T]{size: n.size + 1, slots: make([]hamtSlot[T], len(n.slots), len(n.slots)+1)}
+		copy(r.slots, n.slots)
+		r.slots = append(r.slots, hamtSlot[T]{hash: h, val: v})
+		return r
+	}
+
+	bit := bitOf(h, shift)
+	i := n.index(bit)
+	if n.bitmap&bit == 0 {
+		r := &hamtNode[T]{bitmap: n.bitmap | bit, size: n.size + 1, slots: make([]hamtSlot[T], len(n.slots)+1)}
+		copy(r.slots, n.slots[:i])
+		r.slots[i] = hamtSlot[T]{hash: h, val: v}
+		copy(r.slots[i+1:], n.slots[i:])
+		return r
+	}
+
+	s := &n.slots[i]
+	var c *hamtNode[T]
+	switch {
+	case s.child != nil:
+		if c = s.child.with(h, v, shift+hamtBits); c == s.child {
+			return n
+		}
+	case s.hash == h && s.val == v:
+		return n
+	default:
+		c = newHamtPair(s.hash, s.val, h, v, shift+hamtBits)
+	}
+	return n.replace(i, hamtSlot[T]{child: c}, 1)
+}
+
+// without returns a new node with v removed, which shares unchanged subtrees with n.
+// It returns n itself if v doesn't exist, and nil if the new node is empty
+func (n *hamtNode[T]) without(h uint64, v T, shift uint) *hamtNode[T] {
+	if shift >= 64 {
+		for i, s := range n.slots {
+			if s.val == v {
+				return n.remove(i, 0)
+			}
+		}
+		return n
+	}
+
+	bit := bitOf(h, shift)
+	if n.bitmap&bit == 0 {
+		return n
+	}
+	i := n.index(bit)
+	s := &n.slots[i]
+	if s.child == nil {
+		if s.hash != h || s.val != v {
+			return n
+		}
+		return n.remove(i, bit)
+	}
+
+	c := s.child.without(h, v, shift+hamtBits)
+	switch {
+	case c == s.child:
+		return n
+	case c.size == 1:
+		// the child only contains one value, replace it by the value
+		return n.replace(i, c.slots[0], -1)
+	default:
+		return n.replace(i, hamtSlot[T]{child: c}, -1)
+	}
+}
+
+// replace returns a copy of n whose i-th slot is replaced by s, and size is changed by delta
+func (n *hamtNode[T]) replace(i int, s hamtSlot[T], delta int) *hamtNode[T] {
+	r := &hamtNode[T]{bitmap: n.bitmap, size: n.size + delta, slots: make([]hamtSlot[T], len(n.slots))}
+	copy(r.slots, n.slots)
+	r.slots[i] = s
+	return r
+}
+
+// remove returns a copy of n without the i-th slot which is a value, and nil if the copy is empty
+func (n *hamtNode[T]) remove(i int, bit uint32) *hamtNode[T] {
+	if n.size == 1 {
+		return nil
+	}
+	r := &hamtNode[T]{bitmap: n.bitmap &^ bit, size: n.size - 1, slots: make([]hamtSlot[T], 0, len(n.slots)-1)}
+	r.slots = append(r.slots, n.slots[:i]...)
+	r.slots = append(r.slots, n.slots[i+1:]...)
+	return r
+}
+
+// each calls fn on every value in the subtree until fn returns false, it returns false if stopped
+func (n *hamtNode[T]) each(fn func(v T) bool) bool {
+	for i := range n.slots {
+		s := &n.slots[i]
+		if s.child != nil {
+			if !s.child.each(fn) {
+				return false
+			}
+		} else if !fn(s.val) {
+			return false
+		}
+	}
+	return true
+}
+
+// newHamtPair returns a new node at shift containing v1 and v2, which are different values
+func newHamtPair[T comparable](h1 uint64, v1 T, h2 uint64, v2 T, shift uint) *hamtNode[T] {
+	a, b := hamtSlot[T]{hash: h1, val: v1}, hamtSlot[T]{hash: h2, val: v2}
+	if shift >= 64 {
+		return &hamtNode[T]{size: 2, slots: []hamtSlot[T]{a, b}}
+	}
+	b1, b2 := bitOf(h1, shift), bitOf(h2, shift)
+	if b1 == b2 {
+		c := newHamtPair(h1, v1, h2, v2, shift+hamtBits)
+		return &hamtNode[T]{bitmap: b1, size: 2, slots: []hamtSlot[T]{{child: c}}}
+	}
+	if b1 > b2 {
+		a, b = b, a
+	}
+	return &hamtNode[T]{bitmap: b1 | b2, size: 2, slots: []hamtSlot[T]{a, b}}
+}
+
+// hamtUnion returns a node containing values of both a and b, subtrees only existing in one of them are shared
+func hamtUnion[T comparable](a, b *hamtNode[T], shift uint) *hamtNode[T] {
+	if a == b {
+		return a
+	}
+	if shift >= 64 {
+		r := a
+		for _, s := range b.slots {
+			r = r.with(s.hash, s.val, shift)
+		}
+		return r
+	}
+
+	bitmap := a.bitmap | b.bitmap
+	r := &hamtNode[T]{bitmap: bitmap, slots: make([]hamtSlot[T], 0, bits.OnesCount32(bitmap))}
+	for bm := bitmap; bm != 0; bm &= bm - 1 {
+		bit := bm & -bm
+		var s hamtSlot[T]
+		switch {
+		case a.bitmap&bit == 0:
+			s = b.slots[b.index(bit)]
+		case b.bitmap&bit == 0:
+			s = a.slots[a.index(bit)]
+		default:
+			s = unionSlot(a.slots[a.index(bit)], b.slots[b.index(bit)], shift+hamtBits)
+		}
+		r.slots = append(r.slots, s)
+		r.size += s.size()
+	}
+
+	// prefer the existing node if one contains the other
+	if r.size == a.size {
+		return a
+	}
+	if r.size == b.size {
+		return b
+	}
+	return r
+}
+
+// unionSlot returns a slot containing values of both x and y, the returned child is placed at shift
+func unionSlot[T comparable](x, y hamtSlot[T], shift uint) hamtSlot[T] {
+	var c *hamtNode[T]
+	switch {
+	case x.child != nil && y.child != nil:
+		c = hamtUnion(x.child, y.child, shift)
+	case x.child != nil:
+		c = x.child.with(y.hash, y.val, shift)
+	case y.child != nil:
+		c = y.child.with(x.hash, x.val, shift)
+	case x.hash == y.hash && x.val == y.val:
+		return x
+	default:
+		c = newHamtPair(x.hash, x.val, y.hash, y.val, shift)
+	}
+	return hamtSlot[T]{child: c}
+}
@@ -0,0 +1,10 @@
+//go:build go1.24
+
+package goset
+
+import "hash/maphash"
+
+// hashOf returns the hash of v, equal values have the same hash under the same seed
+func hashOf[T comparable](seed maphash.Seed, v T) uint64 {
+	return maphash.Comparable(seed, v)
+}
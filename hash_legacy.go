@@ -0,0 +1,11 @@
+//go:build !go1.24
+
+package goset
+
+import "hash/maphash"
+
+// hashOf returns the hash of v, equal values have the same hash under the same seed.
+// maphash.Comparable is unavailable before Go 1.24, so v is hashed by reflection, which is slower
+func hashOf[T comparable](seed maphash.Seed, v T) uint64 {
+	return hashValue(seed, v)
+}
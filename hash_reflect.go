@@ -0,0 +1,82 @@
+package goset
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// hashValue returns the hash of v like maphash.Comparable, which is used before Go 1.24.
+// Equal values have the same hash: pointers and channels are hashed by address,
+// 0.0 and -0.0 are hashed the same, and blank fields of structs are ignored
+func hashValue[T comparable](seed maphash.Seed, v T) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	if s, ok := any(v).(string); ok {
+		h.WriteString(s)
+	} else {
+		writeHash(&h, reflect.ValueOf(&v).Elem())
+	}
+	return h.Sum64()
+}
+
+func writeUint64(h *maphash.Hash, x uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+	h.Write(buf[:])
+}
+
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		// -0.0 == 0.0
+		f = 0
+	}
+	writeUint64(h, math.Float64bits(f))
+}
+
+// writeHash writes v of a comparable type to h
+func writeHash(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(h, real(c))
+		writeFloat(h, imag(c))
+	case reflect.String:
+		s := v.String()
+		writeUint64(h, uint64(len(s)))
+		h.WriteString(s)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+		} else {
+			h.WriteByte(1)
+			writeHash(h, v.Elem())
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeHash(h, v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).Name != "_" {
+				writeHash(h, v.Field(i))
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package goset
+
+import (
+	"hash/maphash"
+	"math"
+	"testing"
+)
+
+type hashNode struct {
+	X    int
+	name string
+	_    int
+}
+
+func TestHashValue(t *testing.T) {
+	seed := maphash.MakeSeed()
+
+	// pointers are hashed by address, not by the values they point to
+	p := &hashNode{X: 1}
+	h := hashValue(seed, p)
+	p.X = 2
+	if hashValue(seed, p) != h {
+		t.Fatalf("hashValue() of a pointer got unexpected change after the pointee changed")
+	}
+	if hashValue(seed, &hashNode{X: 2}) == h {
+		t.Fatalf("hashValue() of different pointers got unexpected same hash")
+	}
+
+	if hashValue(seed, 0.0) != hashValue(seed, math.Copysign(0, -1)) {
+		t.Fatalf("hashValue() of 0.0 and -0.0 got unexpected different hashes")
+	}
+	if hashValue(seed, complex(0, 0)) != hashValue(seed, complex(math.Copysign(0, -1), 0)) {
+		t.Fatalf("hashValue() of complex zeros got unexpected different hashes")
+	}
+	if hashValue(seed, hashNode{X: 1, name: "a"}) != hashValue(seed, hashNode{X: 1, name: "a"}) ||
+		hashValue(seed, hashNode{X: 1, name: "a"}) == hashValue(seed, hashNode{X: 1, name: "b"}) {
+		t.Fatalf("hashValue() of structs got unexpected result")
+	}
+	if hashValue(seed, [2]string{"ab", "c"}) == hashValue(seed, [2]string{"a", "bc"}) {
+		t.Fatalf("hashValue() of arrays got unexpected same hash")
+	}
+	if hashValue(seed, "a") != hashValue(seed, "a") || hashValue(seed, "a") == hashValue(seed, "b") {
+		t.Fatalf("hashValue() of strings got unexpected result")
+	}
+}
@@ -0,0 +1,181 @@
+package goset
+
+import "hash/maphash"
+
+// immutableSeed is shared by all ImmutableSets, so that their tries can be merged structurally
+var immutableSeed = maphash.MakeSeed()
+
+// ImmutableSet is a persistent set which is never modified after being created, so it's goroutine safe without locks
+// and can be shared without copying.
+// With, Without and Union return new versions sharing most of the structure with the old ones in O(log n).
+// The zero value is an empty set
+type ImmutableSet[T comparable] struct {
+	root *hamtNode[T]
+}
+
+// NewImmutableSet creates a new ImmutableSet
+func NewImmutableSet[T comparable](vals ...T) *ImmutableSet[T] {
+	return (&ImmutableSet[T]{}).With(vals...)
+}
+
+// With returns a new ImmutableSet with vals added, s is left unchanged
+func (s *ImmutableSet[T]) With(vals ...T) *ImmutableSet[T] {
+	r := &ImmutableSet[T]{root: s.root}
+	for _, v := range vals {
+		r.add(v)
+	}
+	if r.root == s.root {
+		return s
+	}
+	return r
+}
+
+// add adds v by replacing the root, it's only used while building a new ImmutableSet
+func (s *ImmutableSet[T]) add(v T) {
+	if s.root == nil {
+		s.root = &hamtNode[T]{}
+	}
+	s.root = s.root.with(hashOf(immutableSeed, v), v, 0)
+}
+
+// Without returns a new ImmutableSet with vals deleted, s is left unchanged
+func (s *ImmutableSet[T]) Without(vals ...T) *ImmutableSet[T] {
+	root := s.root
+	for _, v := range vals {
+		if root == nil {
+			break
+		}
+		root = root.without(hashOf(immutableSeed, v), v, 0)
+	}
+	if root == s.root {
+		return s
+	}
+	return &ImmutableSet[T]{root: root}
+}
+
+// Length returns ImmutableSet length
+func (s *ImmutableSet[T]) Length() int {
+	if s.root == nil {
+		return 0
+	}
+	return s.root.size
+}
+
+// Has returns whether v exists in ImmutableSet
+func (s *ImmutableSet[T]) Has(v T) bool {
+	if s.root == nil {
+		return false
+	}
+	return s.root.has(hashOf(immutableSeed, v), v, 0)
+}
+
+// ToList returns data slice
+func (s *ImmutableSet[T]) ToList() []T {
+	r := make([]T, 0, s.Length())
+	if s.root != nil {
+		s.root.each(func(v T) bool {
+			r = append(r, v)
+			return true
+		})
+	}
+	return r
+}
+
+// Equals returns whether ImmutableSet s has the same members with set t
+func (s *ImmutableSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*ImmutableSet[T]); ok && s.root == o.root {
+		return true
+	}
+	return equals[T](s, t)
+}
+
+// IsSub returns whether it's a part of set t
+func (s *ImmutableSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*ImmutableSet[T]); ok && s.root == o.root {
+		return true
+	}
+	return isSub[T](s, t)
+}
+
+// Union unions with set t and returns a new ImmutableSet.
+// If t is an ImmutableSet, subtrees only existing in one of them are shared instead of being copied
+func (s *ImmutableSet[T]) Union(t ReadableSet[T]) *ImmutableSet[T] {
+	if isNil(t) {
+		return s
+	}
+	o, ok := t.(*ImmutableSet[T])
+	if !ok {
+		return s.With(t.ToList()...)
+	}
+	switch {
+	case o.root == nil:
+		return s
+	case s.root == nil:
+		return o
+	}
+	if root := hamtUnion(s.root, o.root, 0); root != s.root {
+		return &ImmutableSet[T]{root: root}
+	}
+	return s
+}
+
+// ToMutable returns a new Set with the same elements
+func (s *ImmutableSet[T]) ToMutable() *Set[T] {
+	r := NewSet[T]()
+	if s.root != nil {
+		s.root.each(func(v T) bool {
+			r.u.data[v] = struct{}{}
+			return true
+		})
+	}
+	return r
+}
+
+// Freeze returns an ImmutableSet with the same elements, later modifications of s don't affect it
+func (s *Set[T]) Freeze() *ImmutableSet[T] {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	r := &ImmutableSet[T]{}
+	for v := range s.u.data {
+		r.add(v)
+	}
+	return r
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *ImmutableSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	return relation[T](s, t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *ImmutableSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *ImmutableSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *ImmutableSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *ImmutableSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	return disjoint[T](s, t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *ImmutableSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
+}
@@ -0,0 +1,173 @@
+package goset
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkHamt checks sizes of n and its children, and that no child node contains only one value
+func checkHamt[T comparable](t *testing.T, n *hamtNode[T]) int {
+	t.Helper()
+	var size int
+	for i := range n.slots {
+		s := &n.slots[i]
+		if s.child == nil {
+			size++
+			continue
+		}
+		if s.child.size < 2 {
+			t.Fatalf("child node got unexpected size %d", s.child.size)
+		}
+		size += checkHamt(t, s.child)
+	}
+	if size != n.size {
+		t.Fatalf("node size got unexpected %d, should be %d", n.size, size)
+	}
+	return size
+}
+
+func TestImmutableSet(t *testing.T) {
+	var zero ImmutableSet[string]
+	if zero.Has("a") || zero.Length() != 0 || len(zero.ToList()) != 0 {
+		t.Fatalf("zero ImmutableSet got unexpected %v", zero.ToList())
+	}
+	if r := zero.Without("a"); r != &zero {
+		t.Fatalf("zero.Without(\"a\") got unexpected %v", r.ToList())
+	}
+
+	s := NewImmutableSet(1, -1, 5)
+	if r := s.Length(); r != 3 {
+		t.Fatalf("s.Length() got unexpected %d", r)
+	}
+	if !s.Has(-1) || s.Has(2) {
+		t.Fatalf("s.Has() got unexpected result")
+	}
+
+	w := s.With(2, 5)
+	if !w.Equals(NewSet(1, -1, 5, 2)) || !s.Equals(NewSet(1, -1, 5)) {
+		t.Fatalf("s.With(2, 5) got unexpected %v, s got %v", w.ToList(), s.ToList())
+	}
+	if r := s.With(1, 5); r != s {
+		t.Fatalf("s.With(1, 5) should return s itself")
+	}
+	wo := w.Without(1, 10)
+	if !wo.Equals(NewSet(-1, 5, 2)) || !w.Equals(NewSet(1, -1, 5, 2)) {
+		t.Fatalf("w.Without(1, 10) got unexpected %v, w got %v", wo.ToList(), w.ToList())
+	}
+	if r := wo.Without(-1, 5, 2); r.Length() != 0 || r.Has(2) {
+		t.Fatalf("wo.Without(-1, 5, 2) got unexpected %v", r.ToList())
+	}
+
+	if r := s.Union(NewImmutableSet(5, 7)); !r.Equals(NewSet(1, -1, 5, 7)) {
+		t.Fatalf("s.Union() got unexpected %v", r.ToList())
+	}
+	if r := s.Union(NewFifoSet(8)); !r.Equals(NewSet(1, -1, 5, 8)) {
+		t.Fatalf("s.Union(FifoSet) got unexpected %v", r.ToList())
+	}
+	if r := s.Union(s.Without(1)); r != s {
+		t.Fatalf("s.Union(sub of s) should return s itself")
+	}
+	if !s.IsSub(w) || w.IsSub(s) || !s.IsSub(NewSet(1, -1, 5, 6)) {
+		t.Fatalf("s.IsSub() got unexpected result")
+	}
+
+	m := s.ToMutable()
+	m.Add(9)
+	if !m.Equals(NewSet(1, -1, 5, 9)) || s.Has(9) {
+		t.Fatalf("s.ToMutable() got unexpected %v, s got %v", m.ToList(), s.ToList())
+	}
+	f := m.Freeze()
+	m.Delete(1)
+	if !f.Equals(NewSet(1, -1, 5, 9)) {
+		t.Fatalf("m.Freeze() got unexpected %v", f.ToList())
+	}
+	if r := NewSet(1, 2).Intersect(f); !r.Equals(NewSet(1)) {
+		t.Fatalf("Set.Intersect(ImmutableSet) got unexpected %v", r.ToList())
+	}
+}
+
+func TestImmutableSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	versions := []*ImmutableSet[int]{{}}
+	models := []map[int]bool{{}}
+	for i := 0; i < 3000; i++ {
+		k := r.Intn(len(versions))
+		s, model := versions[k], models[k]
+		next := make(map[int]bool, len(model)+1)
+		for v := range model {
+			next[v] = true
+		}
+
+		v := r.Intn(500)
+		var n *ImmutableSet[int]
+		switch r.Intn(3) {
+		case 0:
+			n = s.Without(v)
+			delete(next, v)
+		case 1:
+			o := versions[r.Intn(len(versions))]
+			n = s.Union(o)
+			for _, v := range o.ToList() {
+				next[v] = true
+			}
+		default:
+			n = s.With(v)
+			next[v] = true
+		}
+		versions = append(versions, n)
+		models = append(models, next)
+	}
+
+	// every version is unchanged by later operations
+	for k, s := range versions {
+		if s.Length() != len(models[k]) {
+			t.Fatalf("version %d Length() got unexpected %d, should be %d", k, s.Length(), len(models[k]))
+		}
+		for v := 0; v < 500; v++ {
+			if s.Has(v) != models[k][v] {
+				t.Fatalf("version %d Has(%d) got unexpected %v", k, v, s.Has(v))
+			}
+		}
+		if s.root != nil {
+			checkHamt(t, s.root)
+		}
+	}
+}
+
+func TestHamtCollision(t *testing.T) {
+	// values with the same hash are stored in collision nodes
+	n := &hamtNode[int]{}
+	for v := 0; v < 5; v++ {
+		n = n.with(42, v, 0)
+	}
+	n = n.with(7, 100, 0)
+	checkHamt(t, n)
+	if n.size != 6 || !n.has(42, 3, 0) || n.has(42, 5, 0) || !n.has(7, 100, 0) {
+		t.Fatalf("hamt with collisions got unexpected size %d", n.size)
+	}
+
+	u := hamtUnion(n, (&hamtNode[int]{}).with(42, 5, 0).with(42, 0, 0), 0)
+	checkHamt(t, u)
+	if u.size != 7 || !u.has(42, 5, 0) {
+		t.Fatalf("hamtUnion() with collisions got unexpected size %d", u.size)
+	}
+
+	for v := 0; v < 5; v++ {
+		n = n.without(42, v, 0)
+		checkHamt(t, n)
+	}
+	if n.size != 1 || !n.has(7, 100, 0) || len(n.slots) != 1 || n.slots[0].child != nil {
+		t.Fatalf("hamt without collisions got unexpected size %d", n.size)
+	}
+}
+
+func BenchmarkImmutableSetWith(b *testing.B) {
+	s := NewImmutableSet[int]()
+	for i := 0; i < 1000; i++ {
+		s = s.With(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.With(i + 1000)
+	}
+}
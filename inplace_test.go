@@ -0,0 +1,112 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+// inPlaceSet is implemented by sets supporting in-place set algebra
+type inPlaceSet interface {
+	Interface[int]
+	UnionWith(t ReadableSet[int]) int
+	IntersectWith(t ReadableSet[int]) int
+	SubtractWith(t ReadableSet[int]) int
+	SymmetricDifferenceWith(t ReadableSet[int]) int
+}
+
+func TestInPlace(t *testing.T) {
+	for _, k := range setKinds {
+		if _, ok := k.new().(inPlaceSet); !ok {
+			continue
+		}
+		newSet := func(vals ...int) inPlaceSet { return k.new(vals...).(inPlaceSet) }
+		t.Run(k.name, func(t *testing.T) {
+			operands := map[string]func(vals ...int) ReadableSet[int]{
+				"same":   func(vals ...int) ReadableSet[int] { return newSet(vals...) },
+				"Set":    func(vals ...int) ReadableSet[int] { return NewSet(vals...) },
+				"sorted": func(vals ...int) ReadableSet[int] { return NewSortedSet(vals...) },
+			}
+			for on, newOperand := range operands {
+				s := newSet(1, 2, 3)
+				if n := s.UnionWith(newOperand(3, 4, 5)); n != 2 || !s.Equals(NewSet(1, 2, 3, 4, 5)) {
+					t.Fatalf("UnionWith(%s) got unexpected %d, %v", on, n, s.ToList())
+				}
+				if n := s.IntersectWith(newOperand(2, 3, 4, 9)); n != 2 || !s.Equals(NewSet(2, 3, 4)) {
+					t.Fatalf("IntersectWith(%s) got unexpected %d, %v", on, n, s.ToList())
+				}
+				if n := s.SubtractWith(newOperand(4, 9)); n != 1 || !s.Equals(NewSet(2, 3)) {
+					t.Fatalf("SubtractWith(%s) got unexpected %d, %v", on, n, s.ToList())
+				}
+				if n := s.SymmetricDifferenceWith(newOperand(3, 7)); n != 2 || !s.Equals(NewSet(2, 7)) {
+					t.Fatalf("SymmetricDifferenceWith(%s) got unexpected %d, %v", on, n, s.ToList())
+				}
+			}
+
+			s := newSet(1, 2)
+			if s.UnionWith(nil) != 0 || s.SubtractWith(nil) != 0 || s.SymmetricDifferenceWith(nil) != 0 {
+				t.Fatalf("in-place operations with nil got unexpected %v", s.ToList())
+			}
+			if n := s.IntersectWith(nil); n != 2 || s.Length() != 0 {
+				t.Fatalf("IntersectWith(nil) got unexpected %d, %v", n, s.ToList())
+			}
+
+			s = newSet(1, 2)
+			if s.UnionWith(s) != 0 || s.IntersectWith(s) != 0 || !s.Equals(NewSet(1, 2)) {
+				t.Fatalf("in-place operations with itself got unexpected %v", s.ToList())
+			}
+			if n := s.SymmetricDifferenceWith(s); n != 2 || s.Length() != 0 {
+				t.Fatalf("SymmetricDifferenceWith(itself) got unexpected %d, %v", n, s.ToList())
+			}
+			s.Add(1, 2)
+			if n := s.SubtractWith(s); n != 2 || s.Length() != 0 {
+				t.Fatalf("SubtractWith(itself) got unexpected %d, %v", n, s.ToList())
+			}
+		})
+	}
+}
+
+func TestInPlaceOrder(t *testing.T) {
+	fifo := NewFifoSet(1, 2, 3)
+	fifo.UnionWith(NewFifoSet(5, 4, 2))
+	fifo.SymmetricDifferenceWith(NewFifoSet(1, 6))
+	if r := fifo.ToList(); !reflect.DeepEqual(r, []int{2, 3, 5, 4, 6}) {
+		t.Fatalf("FifoSet in-place operations got unexpected %v", r)
+	}
+
+	filo := NewFiloSet(1, 2, 3)
+	filo.UnionWith(NewFifoSet(5, 4, 2))
+	if r := filo.ToList(); !reflect.DeepEqual(r, []int{5, 4, 3, 2, 1}) {
+		t.Fatalf("FiloSet.UnionWith() got unexpected %v", r)
+	}
+
+	sorted := NewSortedSet(5, 1, 3)
+	sorted.UnionWith(NewSet(4, 2))
+	sorted.IntersectWith(NewSet(1, 2, 4, 5))
+	checkSkipList(t, sorted.u.list)
+	if r := sorted.ToList(); !reflect.DeepEqual(r, []int{1, 2, 4, 5}) {
+		t.Fatalf("SortedSet in-place operations got unexpected %v", r)
+	}
+}
+
+func TestSetSnapshotInPlace(t *testing.T) {
+	s := NewSet(1, 2, 3)
+	v := s.Snapshot()
+	s.IntersectWith(NewSet(1))
+	s.UnionWith(NewSet(7))
+	if !v.Equals(NewSet(1, 2, 3)) || !s.Equals(NewSet(1, 7)) {
+		t.Fatalf("in-place operations after Snapshot() got unexpected %v, snapshot got %v", s.ToList(), v.ToList())
+	}
+}
+
+func BenchmarkUnionWith(b *testing.B) {
+	vals := make([]int, 1000)
+	for i := range vals {
+		vals[i] = i
+	}
+	t := NewSet(vals...)
+	s := NewSet[int]()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.UnionWith(t)
+	}
+}
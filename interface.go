@@ -0,0 +1,187 @@
+package goset
+
+import "reflect"
+
+// ReadableSet is the read-only part of Interface
+type ReadableSet[T comparable] interface {
+	// Length returns the number of elements
+	Length() int
+	// Has returns whether v exists in the set
+	Has(v T) bool
+	// ToList returns data slice
+	ToList() []T
+	// Equals returns whether the set has the same members with set t
+	Equals(t ReadableSet[T]) bool
+	// IsSub returns whether the set is a part of set t
+	IsSub(t ReadableSet[T]) bool
+}
+
+// Interface is the common interface implemented by Set, FifoSet, FiloSet and SortedSet,
+// so that a set can be accepted regardless of how its elements are stored
+type Interface[T comparable] interface {
+	ReadableSet[T]
+	// Add adds elements
+	Add(vals ...T)
+	// Delete deletes elements
+	Delete(vals ...T)
+	// Clear clears all elements
+	Clear()
+}
+
+var (
+	_ Interface[int] = (*Set[int])(nil)
+	_ Interface[int] = (*FifoSet[int])(nil)
+	_ Interface[int] = (*FiloSet[int])(nil)
+	_ Interface[int] = (*LRUSet[int])(nil)
+	_ Interface[int] = (*SortedSet[int])(nil)
+	_ Interface[int] = (*ShardedSet[int])(nil)
+	_ Interface[int] = (*UnsafeSet[int])(nil)
+	_ Interface[int] = (*UnsafeSortedSet[int])(nil)
+	_ Interface[int] = (*SyncSet[int])(nil)
+	_ Interface[int] = (*TTLSet[int])(nil)
+
+	_ ReadableSet[int] = (*ImmutableSet[int])(nil)
+	_ ReadableSet[int] = (*SetSnapshot[int])(nil)
+)
+
+// isNil returns whether t is nil or a nil pointer, so that a typed nil operand is treated as nil.
+// Sets of this package are checked without reflect, which is only used for other implementations
+func isNil[T comparable](t ReadableSet[T]) bool {
+	switch o := t.(type) {
+	case nil:
+		return true
+	case *Set[T]:
+		return o == nil
+	case *FifoSet[T]:
+		return o == nil
+	case *FiloSet[T]:
+		return o == nil
+	case *LRUSet[T]:
+		return o == nil
+	case *SortedSet[T]:
+		return o == nil
+	case *ShardedSet[T]:
+		return o == nil
+	case *UnsafeSet[T]:
+		return o == nil
+	case *UnsafeSortedSet[T]:
+		return o == nil
+	case *SyncSet[T]:
+		return o == nil
+	case *TTLSet[T]:
+		return o == nil
+	case *ImmutableSet[T]:
+		return o == nil
+	case *SetSnapshot[T]:
+		return o == nil
+	case *linearSet[T]:
+		return o == nil
+	}
+	v := reflect.ValueOf(t)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// toucher is implemented by sets whose Has changes the order of elements, such as LRUSet
+type toucher[T comparable] interface {
+	// untouched returns a view of the set whose Has doesn't change it
+	untouched() ReadableSet[T]
+}
+
+// untouched returns a view of t whose Has doesn't change t, so that t isn't changed by being an operand
+func untouched[T comparable](t ReadableSet[T]) ReadableSet[T] {
+	if o, ok := t.(toucher[T]); ok {
+		return o.untouched()
+	}
+	return t
+}
+
+// isSub returns whether every element of s exists in t
+func isSub[T comparable](s, t ReadableSet[T]) bool {
+	t = untouched(t)
+	if s.Length() > t.Length() {
+		return false
+	}
+	for _, v := range s.ToList() {
+		if !t.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// equals returns whether s and t have the same members
+func equals[T comparable](s, t ReadableSet[T]) bool {
+	return s.Length() == t.Length() && isSub(s, t)
+}
+
+// unionWith adds elements of t to s, and returns the number of added elements.
+// s must not hold any lock, since t is read while modifying s
+func unionWith[T comparable](s Interface[T], t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := t.ToList()
+	if _, ok := s.(toucher[T]); ok {
+		// Add moves existing elements of an LRUSet, so only the missing ones are added
+		u := untouched[T](s)
+		add := vals[:0]
+		for _, v := range vals {
+			if !u.Has(v) {
+				add = append(add, v)
+			}
+		}
+		vals = add
+	}
+	n := s.Length()
+	s.Add(vals...)
+	return s.Length() - n
+}
+
+// intersectWith deletes elements of s which don't exist in t, and returns the number of deleted elements
+func intersectWith[T comparable](s Interface[T], t ReadableSet[T]) int {
+	if isNil(t) {
+		n := s.Length()
+		s.Clear()
+		return n
+	}
+	t = untouched(t)
+	var drop []T
+	for _, v := range s.ToList() {
+		if !t.Has(v) {
+			drop = append(drop, v)
+		}
+	}
+	s.Delete(drop...)
+	return len(drop)
+}
+
+// subtractWith deletes elements of s which exist in t, and returns the number of deleted elements
+func subtractWith[T comparable](s Interface[T], t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	n := s.Length()
+	s.Delete(t.ToList()...)
+	return n - s.Length()
+}
+
+// symmetricDifferenceWith deletes elements of s which exist in t and adds the others of t,
+// and returns the number of deleted and added elements
+func symmetricDifferenceWith[T comparable](s Interface[T], t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := t.ToList()
+	u := untouched[T](s)
+	var add, drop []T
+	for _, v := range vals {
+		if u.Has(v) {
+			drop = append(drop, v)
+		} else {
+			add = append(add, v)
+		}
+	}
+	s.Delete(drop...)
+	s.Add(add...)
+	return len(vals)
+}
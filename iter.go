@@ -0,0 +1,169 @@
+//go:build go1.23
+
+package goset
+
+import "iter"
+
+// All returns an iterator over elements of Set.
+// The read lock is held during iteration, so the Set must not be modified in the loop body
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.m.RLock()
+		defer s.m.RUnlock()
+
+		for v := range s.u.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements from head to tail, in the same order as ToList.
+// The read lock is held during iteration, so the set must not be modified in the loop body
+func (s *linearSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.m.RLock()
+		defer s.m.RUnlock()
+
+		for cur := s.head; cur != nil; cur = cur.next {
+			if !yield(cur.val) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over elements from tail to head, in the reverse order of ToList.
+// The read lock is held during iteration, so the set must not be modified in the loop body
+func (s *linearSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.m.RLock()
+		defer s.m.RUnlock()
+
+		for cur := s.tail; cur != nil; cur = cur.pre {
+			if !yield(cur.val) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements in asc order.
+// The read lock is held during iteration, so the SortedSet must not be modified in the loop body
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.m.RLock()
+		defer s.m.RUnlock()
+
+		for x := s.u.list.first(); x != nil; x = x.levels[0].next {
+			if !yield(x.val) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over elements in desc order.
+// The read lock is held during iteration, so the SortedSet must not be modified in the loop body
+func (s *SortedSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.m.RLock()
+		defer s.m.RUnlock()
+
+		for x := s.u.list.tail; x != nil; x = x.pre {
+			if !yield(x.val) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements of ShardedSet.
+// The read locks of all shards are held during iteration, so the ShardedSet must not be modified in the loop body
+func (s *ShardedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.rlockAll()
+		defer s.runlockAll()
+
+		for i := range s.shards {
+			for v := range s.shards[i].data {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements of UnsafeSet
+func (s *UnsafeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements in asc order
+func (s *UnsafeSortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := s.list.first(); x != nil; x = x.levels[0].next {
+			if !yield(x.val) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over elements in desc order
+func (s *UnsafeSortedSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := s.list.tail; x != nil; x = x.pre {
+			if !yield(x.val) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements in the order of the wrapped set.
+// The read lock is held during iteration, so the SyncSet must not be modified in the loop body
+func (s *SyncSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.m.RLock()
+		defer s.m.RUnlock()
+
+		if a, ok := s.s.(interface{ All() iter.Seq[T] }); ok {
+			a.All()(yield)
+			return
+		}
+		for _, v := range s.s.ToList() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements of ImmutableSet
+func (s *ImmutableSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.root != nil {
+			s.root.each(yield)
+		}
+	}
+}
+
+// All returns an iterator over elements of SetSnapshot, it never blocks writers of the Set
+func (s *SetSnapshot[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.u.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build go1.23
+
+package goset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIter(t *testing.T) {
+	s := NewSet(1, 2, 3)
+	var n int
+	for v := range s.All() {
+		if !s.Has(v) {
+			t.Fatalf("s.All() got unexpected %d", v)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Fatalf("s.All() got unexpected %d elements", n)
+	}
+
+	f := NewFifoSet("e", "a", "b", "a", "c")
+	var r []string
+	for v := range f.All() {
+		r = append(r, v)
+	}
+	if !reflect.DeepEqual(r, []string{"e", "a", "b", "c"}) {
+		t.Fatalf("f.All() got unexpected %v", r)
+	}
+	r = r[:0]
+	for v := range f.Backward() {
+		if v == "a" {
+			break
+		}
+		r = append(r, v)
+	}
+	if !reflect.DeepEqual(r, []string{"c", "b"}) {
+		t.Fatalf("f.Backward() got unexpected %v", r)
+	}
+
+	z := NewSortedSet(5, -1, 3)
+	r2 := []int{}
+	for v := range z.All() {
+		r2 = append(r2, v)
+	}
+	if !reflect.DeepEqual(r2, []int{-1, 3, 5}) {
+		t.Fatalf("z.All() got unexpected %v", r2)
+	}
+
+	r2 = r2[:0]
+	for v := range Synchronized[int](NewUnsafeSortedSet(2, 3, 1)).All() {
+		r2 = append(r2, v)
+	}
+	if !reflect.DeepEqual(r2, []int{1, 2, 3}) {
+		t.Fatalf("SyncSet.All() got unexpected %v", r2)
+	}
+	r2 = r2[:0]
+	for v := range Synchronized[int](NewTTLSet(time.Hour, 1)).All() {
+		r2 = append(r2, v)
+	}
+	if !reflect.DeepEqual(r2, []int{1}) {
+		t.Fatalf("SyncSet.All() of TTLSet got unexpected %v", r2)
+	}
+}
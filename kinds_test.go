@@ -0,0 +1,22 @@
+package goset
+
+import "time"
+
+// setKinds creates sets of every kind in this package, tests pick the kinds implementing the methods under test
+var setKinds = []struct {
+	name string
+	new  func(vals ...int) ReadableSet[int]
+}{
+	{"Set", func(vals ...int) ReadableSet[int] { return NewSet(vals...) }},
+	{"FifoSet", func(vals ...int) ReadableSet[int] { return NewFifoSet(vals...) }},
+	{"FiloSet", func(vals ...int) ReadableSet[int] { return NewFiloSet(vals...) }},
+	{"LRUSet", func(vals ...int) ReadableSet[int] { return NewLRUSet(100, vals...) }},
+	{"SortedSet", func(vals ...int) ReadableSet[int] { return NewSortedSet(vals...) }},
+	{"ShardedSet", func(vals ...int) ReadableSet[int] { return NewShardedSet(vals...) }},
+	{"UnsafeSet", func(vals ...int) ReadableSet[int] { return NewUnsafeSet(vals...) }},
+	{"UnsafeSortedSet", func(vals ...int) ReadableSet[int] { return NewUnsafeSortedSet(vals...) }},
+	{"SyncSet", func(vals ...int) ReadableSet[int] { return Synchronized[int](NewUnsafeSet(vals...)) }},
+	{"TTLSet", func(vals ...int) ReadableSet[int] { return NewTTLSet(time.Hour, vals...) }},
+	{"ImmutableSet", func(vals ...int) ReadableSet[int] { return NewImmutableSet(vals...) }},
+	{"SetSnapshot", func(vals ...int) ReadableSet[int] { return NewSet(vals...).Snapshot() }},
+}
@@ -0,0 +1,235 @@
+package goset
+
+import "fmt"
+
+// Methods below edit positions of elements in the order of ToList, the index of the first element is 0.
+// They're exported by FifoSet and FiloSet only, since LRUSet orders elements by access
+
+// InsertBefore inserts v before mark, and returns false if mark doesn't exist or v exists.
+// Use MoveBefore to move an existing element.
+// If a bounded FifoSet is full, the first element is evicted, and it returns false if v is the evicted one
+//
+// for example:
+// var s=NewFifoSet(1,3)
+// s.InsertBefore(3, 2)
+// s.ToList() returns [1,2,3]
+func (s *FifoSet[T]) InsertBefore(mark, v T) bool {
+	return s.insertBefore(mark, v)
+}
+
+// InsertAfter inserts v after mark, and returns false if mark doesn't exist or v exists.
+// Use MoveAfter to move an existing element.
+// If a bounded FifoSet is full, the first element is evicted, and it returns false if v is the evicted one
+func (s *FifoSet[T]) InsertAfter(mark, v T) bool {
+	return s.insertAfter(mark, v)
+}
+
+// MoveBefore moves v before mark, and returns false if v or mark doesn't exist
+func (s *FifoSet[T]) MoveBefore(v, mark T) bool {
+	return s.moveBefore(v, mark)
+}
+
+// MoveAfter moves v after mark, and returns false if v or mark doesn't exist
+func (s *FifoSet[T]) MoveAfter(v, mark T) bool {
+	return s.moveAfter(v, mark)
+}
+
+// Swap swaps the positions of a and b, and returns false if a or b doesn't exist
+func (s *FifoSet[T]) Swap(a, b T) bool {
+	return s.swap(a, b)
+}
+
+// IndexOf returns the index of v, or -1 if v doesn't exist
+func (s *FifoSet[T]) IndexOf(v T) int {
+	return s.indexOf(v)
+}
+
+// At returns the element at index i, the first in element is at 0.
+// It panics if i is out of range
+func (s *FifoSet[T]) At(i int) T {
+	return s.at(i)
+}
+
+// Reverse reverses the order of elements in place
+func (s *FifoSet[T]) Reverse() {
+	s.reverse()
+}
+
+// InsertBefore inserts v before mark, see FifoSet.InsertBefore
+func (s *FiloSet[T]) InsertBefore(mark, v T) bool {
+	return s.insertBefore(mark, v)
+}
+
+// InsertAfter inserts v after mark, see FifoSet.InsertAfter
+func (s *FiloSet[T]) InsertAfter(mark, v T) bool {
+	return s.insertAfter(mark, v)
+}
+
+// MoveBefore moves v before mark, and returns false if v or mark doesn't exist
+func (s *FiloSet[T]) MoveBefore(v, mark T) bool {
+	return s.moveBefore(v, mark)
+}
+
+// MoveAfter moves v after mark, and returns false if v or mark doesn't exist
+func (s *FiloSet[T]) MoveAfter(v, mark T) bool {
+	return s.moveAfter(v, mark)
+}
+
+// Swap swaps the positions of a and b, and returns false if a or b doesn't exist
+func (s *FiloSet[T]) Swap(a, b T) bool {
+	return s.swap(a, b)
+}
+
+// IndexOf returns the index of v, or -1 if v doesn't exist
+func (s *FiloSet[T]) IndexOf(v T) int {
+	return s.indexOf(v)
+}
+
+// At returns the element at index i, the top element is at 0.
+// It panics if i is out of range
+func (s *FiloSet[T]) At(i int) T {
+	return s.at(i)
+}
+
+// Reverse reverses the order of elements in place
+func (s *FiloSet[T]) Reverse() {
+	s.reverse()
+}
+
+func (s *linearSet[T]) insertBefore(mark, v T) bool {
+	var ok bool
+	s.bounded(func() {
+		ok = s.insert(v, mark, false)
+	})
+	return ok
+}
+
+func (s *linearSet[T]) insertAfter(mark, v T) bool {
+	var ok bool
+	s.bounded(func() {
+		ok = s.insert(v, mark, true)
+	})
+	return ok
+}
+
+// insert puts v before or after mark, and returns whether it's inserted and won't be evicted.
+// It must be called with the write lock held in bounded
+func (s *linearSet[T]) insert(v, mark T, after bool) bool {
+	m, ok := s.data[mark]
+	if !ok {
+		return false
+	}
+	if _, ok := s.data[v]; ok {
+		return false
+	}
+	n := &setNode[T]{val: v}
+	if after {
+		s.linkBefore(n, m.next)
+	} else {
+		s.linkBefore(n, m)
+	}
+	s.data[v] = n
+	s.added.Signal()
+	// only one element is beyond the capacity after inserting one, and the head will be evicted by bounded
+	return s.capacity == 0 || len(s.data) <= s.capacity || s.head != n
+}
+
+func (s *linearSet[T]) moveBefore(v, mark T) bool {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.move(v, mark, false)
+}
+
+func (s *linearSet[T]) moveAfter(v, mark T) bool {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.move(v, mark, true)
+}
+
+// move puts v before or after mark, and returns whether both exist.
+// It must be called with the write lock held
+func (s *linearSet[T]) move(v, mark T, after bool) bool {
+	n, ok := s.data[v]
+	if !ok {
+		return false
+	}
+	m, ok := s.data[mark]
+	if !ok {
+		return false
+	}
+	if n == m {
+		return true
+	}
+	s.unlink(n)
+	if after {
+		s.linkBefore(n, m.next)
+	} else {
+		s.linkBefore(n, m)
+	}
+	return true
+}
+
+func (s *linearSet[T]) swap(a, b T) bool {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	na, ok := s.data[a]
+	if !ok {
+		return false
+	}
+	nb, ok := s.data[b]
+	if !ok {
+		return false
+	}
+	na.val, nb.val = b, a
+	s.data[a], s.data[b] = nb, na
+	return true
+}
+
+func (s *linearSet[T]) indexOf(v T) int {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	if _, ok := s.data[v]; !ok {
+		return -1
+	}
+	i := 0
+	for cur := s.head; cur.val != v; cur = cur.next {
+		i++
+	}
+	return i
+}
+
+func (s *linearSet[T]) at(i int) T {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	if i < 0 || i >= len(s.data) {
+		panic(fmt.Sprintf("goset: index %d out of range [0:%d]", i, len(s.data)))
+	}
+	// walk from the nearer end
+	if i < len(s.data)/2 {
+		cur := s.head
+		for ; i > 0; i-- {
+			cur = cur.next
+		}
+		return cur.val
+	}
+	cur := s.tail
+	for i = len(s.data) - 1 - i; i > 0; i-- {
+		cur = cur.pre
+	}
+	return cur.val
+}
+
+func (s *linearSet[T]) reverse() {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	for cur := s.head; cur != nil; cur = cur.pre {
+		cur.pre, cur.next = cur.next, cur.pre
+	}
+	s.head, s.tail = s.tail, s.head
+}
@@ -0,0 +1,104 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinearSetPosition(t *testing.T) {
+	s := NewFifoSet(1, 3, 5)
+	if !s.InsertBefore(3, 2) || !s.InsertAfter(3, 4) || !s.InsertAfter(5, 6) || !s.InsertBefore(1, 0) {
+		t.Fatalf("s.InsertBefore() and s.InsertAfter() got unexpected false")
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{0, 1, 2, 3, 4, 5, 6}) {
+		t.Fatalf("inserting got unexpected %v", r)
+	}
+	if s.InsertBefore(9, 7) || s.InsertAfter(1, 5) || s.Length() != 7 {
+		t.Fatalf("inserting with missing mark or existing element got unexpected %v", s.ToList())
+	}
+	if v, _ := s.Peek(); v != 0 {
+		t.Fatalf("s.Peek() after inserting got unexpected %v", v)
+	}
+
+	if !s.MoveBefore(6, 0) || !s.MoveAfter(0, 3) || !s.MoveAfter(1, 5) || !s.MoveBefore(2, 2) {
+		t.Fatalf("s.MoveBefore() and s.MoveAfter() got unexpected false")
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{6, 2, 3, 0, 4, 5, 1}) {
+		t.Fatalf("moving got unexpected %v", r)
+	}
+	if s.MoveBefore(9, 1) || s.MoveAfter(1, 9) {
+		t.Fatalf("moving with missing elements got unexpected true")
+	}
+
+	if !s.Swap(6, 1) || !s.Swap(3, 3) || s.Swap(1, 9) {
+		t.Fatalf("s.Swap() got unexpected result")
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1, 2, 3, 0, 4, 5, 6}) || !s.Has(6) {
+		t.Fatalf("s.Swap(6, 1) got unexpected %v", r)
+	}
+	s.Delete(6)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1, 2, 3, 0, 4, 5}) {
+		t.Fatalf("s.Delete(6) after swapping got unexpected %v", r)
+	}
+
+	for i, v := range s.ToList() {
+		if s.IndexOf(v) != i {
+			t.Fatalf("s.IndexOf(%d) got unexpected %d", v, s.IndexOf(v))
+		}
+		if r := s.At(i); r != v {
+			t.Fatalf("s.At(%d) got unexpected %v", i, r)
+		}
+	}
+	if s.IndexOf(9) != -1 {
+		t.Fatalf("s.IndexOf(9) got unexpected %d", s.IndexOf(9))
+	}
+	for _, i := range []int{6, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("s.At(%d) got unexpected no panic", i)
+				}
+			}()
+			s.At(i)
+		}()
+	}
+
+	s.Reverse()
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{5, 4, 0, 3, 2, 1}) {
+		t.Fatalf("s.Reverse() got unexpected %v", r)
+	}
+	// At walks back from the tail for the latter half
+	if v := s.At(4); v != 2 {
+		t.Fatalf("s.At(4) after reversing got unexpected %v", v)
+	}
+	s.Add(7)
+	if v, _ := s.Pop(); v != 5 || !reflect.DeepEqual(s.ToList(), []int{4, 0, 3, 2, 1, 7}) {
+		t.Fatalf("s.Pop() after reversing got unexpected %v, %v", v, s.ToList())
+	}
+
+	// positions of FiloSet start from the top
+	filo := NewFiloSet(1, 2, 3)
+	filo.InsertAfter(3, 4)
+	filo.MoveBefore(1, 3)
+	if v, _ := filo.Pop(); v != 1 || !reflect.DeepEqual(filo.ToList(), []int{3, 4, 2}) {
+		t.Fatalf("FiloSet positional editing got unexpected %v, %v", v, filo.ToList())
+	}
+
+	// inserting into a full bounded FifoSet evicts the first in element
+	b := NewBoundedFifoSet(2, 1, 3)
+	if !b.InsertBefore(3, 2) || !reflect.DeepEqual(b.ToList(), []int{2, 3}) || b.Evictions() != 1 {
+		t.Fatalf("inserting into bounded FifoSet got unexpected %v", b.ToList())
+	}
+	// the inserted element is evicted at once if it's put at the front
+	if b.InsertBefore(2, 0) || b.Has(0) || !reflect.DeepEqual(b.ToList(), []int{2, 3}) || b.Evictions() != 2 {
+		t.Fatalf("inserting before the first element of full FifoSet got unexpected %v", b.ToList())
+	}
+	if !b.InsertAfter(2, 9) || !reflect.DeepEqual(b.ToList(), []int{9, 3}) {
+		t.Fatalf("inserting after the first element of full FifoSet got unexpected %v", b.ToList())
+	}
+
+	// LRUSet orders elements by access, so they can't be edited by position
+	if _, ok := any(NewLRUSet(1, 1)).(interface{ Reverse() }); ok {
+		t.Fatalf("LRUSet got unexpected positional editing")
+	}
+}
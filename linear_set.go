@@ -1,11 +1,6 @@
 package goset
 
-import (
-	"reflect"
-	"sync"
-
-	cmp "github.com/visforest/goset/v2/compare"
-)
+import "sync"
 
 func addFifo[T comparable](s *linearSet[T], vals ...T) {
 	if len(vals) == 0 {
@@ -14,28 +9,8 @@ func addFifo[T comparable](s *linearSet[T], vals ...T) {
 	defer s.m.Unlock()
 	s.m.Lock()
 
-	var i int
-	if s.head == nil {
-		// first node
-		n := &setNode[T]{
-			val: vals[i],
-		}
-		s.head = n
-		s.tail = n
-		s.data[vals[i]] = n
-		i++
-	}
-	for ; i < len(vals); i++ {
-		if _, ok := s.data[vals[i]]; !ok {
-			n := &setNode[T]{
-				val:  vals[i],
-				pre:  s.tail,
-				next: nil,
-			}
-			s.tail.next = n
-			s.tail = n
-			s.data[vals[i]] = n
-		}
+	for _, v := range vals {
+		s.pushBack(v)
 	}
 }
 
@@ -46,85 +21,8 @@ func addFilo[T comparable](s *linearSet[T], vals ...T) {
 	defer s.m.Unlock()
 	s.m.Lock()
 
-	var i int
-	if s.tail == nil {
-		// put first value into tail node
-		n := &setNode[T]{
-			val: vals[i],
-		}
-		s.head = n
-		s.tail = n
-		s.data[vals[i]] = n
-		i++
-	}
-	for ; i < len(vals); i++ {
-		if _, ok := s.data[vals[i]]; !ok {
-			n := &setNode[T]{
-				val: vals[i],
-			}
-			n.next = s.head
-			s.head.pre = n
-			s.head = n
-			s.data[vals[i]] = n
-		}
-	}
-}
-
-func addSorted[T cmp.Ordered](s *linearSet[T], vals ...T) {
-	if len(vals) == 0 {
-		return
-	}
-	defer s.m.Unlock()
-	s.m.Lock()
-
-	var i int
-	if s.head == nil {
-		// first node
-		n := &setNode[T]{
-			val: vals[i],
-		}
-		s.head = n
-		s.tail = n
-		s.data[vals[i]] = n
-		i++
-	}
-	for ; i < len(vals); i++ {
-		if _, ok := s.data[vals[i]]; !ok {
-			n := &setNode[T]{
-				val: vals[i],
-			}
-			if cmp.Less(vals[i], s.head.val) {
-				// add to head
-				n.next = s.head
-				s.head.pre = n
-				s.head = n
-				s.data[vals[i]] = n
-			} else if cmp.Less(s.tail.val, vals[i]) {
-				//	add to tail
-				n.pre = s.tail
-				s.tail.next = n
-				s.tail = n
-				s.data[vals[i]] = n
-			} else {
-				// search and insert
-				left := s.head
-				right := left.next
-				for right != nil {
-					if cmp.Less(vals[i], right.val) {
-						// insert and break
-						left.next = n
-						right.pre = n
-						n.pre = left
-						n.next = right
-						s.data[vals[i]] = n
-						break
-					}
-					// go on
-					right = right.next
-					left = left.next
-				}
-			}
-		}
+	for _, v := range vals {
+		s.pushFront(v)
 	}
 }
 
@@ -139,10 +37,18 @@ type linearSet[T comparable] struct {
 	head *setNode[T]
 	tail *setNode[T]
 	data map[T]*setNode[T]
+	// added is signaled whenever an element is added, its lock is the write lock of m
+	added *sync.Cond
+
+	// capacity is the max number of elements, elements are evicted from the head beyond it. 0 means unbounded
+	capacity  int
+	onEvict   func(v T)
+	evictions uint64
 }
 
 func newLinearSet[T comparable](add func(s *linearSet[T], vals ...T), vals ...T) *linearSet[T] {
 	s := &linearSet[T]{data: make(map[T]*setNode[T])}
+	s.added = sync.NewCond(&s.m)
 	add(s, vals...)
 	return s
 }
@@ -152,26 +58,179 @@ func (s *linearSet[T]) Delete(vals ...T) {
 	s.m.Lock()
 
 	for _, v := range vals {
-		if n, ok := s.data[v]; ok {
-			if n.pre == nil {
-				s.head = n.next
-			} else {
-				n.pre.next = n.next
-			}
-			if n.next == nil {
-				s.tail = n.pre
-			} else {
-				n.next.pre = n.pre
-			}
-			delete(s.data, v)
+		s.remove(v)
+	}
+}
+
+// pushBack appends v to the tail if it doesn't exist, and returns whether it's added.
+// It must be called with the write lock held
+func (s *linearSet[T]) pushBack(v T) bool {
+	if _, ok := s.data[v]; ok {
+		return false
+	}
+	n := &setNode[T]{val: v, pre: s.tail}
+	if s.tail == nil {
+		s.head = n
+	} else {
+		s.tail.next = n
+	}
+	s.tail = n
+	s.data[v] = n
+	s.added.Signal()
+	return true
+}
+
+// pushFront inserts v before the head if it doesn't exist, and returns whether it's added.
+// It must be called with the write lock held
+func (s *linearSet[T]) pushFront(v T) bool {
+	if _, ok := s.data[v]; ok {
+		return false
+	}
+	n := &setNode[T]{val: v, next: s.head}
+	if s.head == nil {
+		s.tail = n
+	} else {
+		s.head.pre = n
+	}
+	s.head = n
+	s.data[v] = n
+	s.added.Signal()
+	return true
+}
+
+// bounded runs fn with the write lock held, then evicts elements from the head beyond the capacity.
+// The eviction callback is called after unlocking, so that it can access the set
+func (s *linearSet[T]) bounded(fn func()) {
+	s.m.Lock()
+	fn()
+	var evicted []T
+	for s.capacity > 0 && len(s.data) > s.capacity {
+		v, _ := s.popFront()
+		s.evictions++
+		if s.onEvict != nil {
+			evicted = append(evicted, v)
+		}
+	}
+	onEvict := s.onEvict
+	s.m.Unlock()
+
+	for _, v := range evicted {
+		onEvict(v)
+	}
+}
+
+// popFront removes the head and returns its value, ok is false if linearSet is empty.
+// It must be called with the write lock held
+func (s *linearSet[T]) popFront() (v T, ok bool) {
+	if s.head == nil {
+		return v, false
+	}
+	v = s.head.val
+	s.remove(v)
+	return v, true
+}
+
+// popFrontN removes at most n elements from the head and returns them in order.
+// It must be called with the write lock held
+func (s *linearSet[T]) popFrontN(n int) []T {
+	if n > len(s.data) {
+		n = len(s.data)
+	}
+	if n <= 0 {
+		return nil
+	}
+	r := make([]T, 0, n)
+	for len(r) < n {
+		v, _ := s.popFront()
+		r = append(r, v)
+	}
+	return r
+}
+
+// peekFront returns the value of the head, ok is false if linearSet is empty
+func (s *linearSet[T]) peekFront() (v T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	if s.head == nil {
+		return v, false
+	}
+	return s.head.val, true
+}
+
+// remove unlinks v if it exists, and returns whether it's removed.
+// It must be called with the write lock held
+func (s *linearSet[T]) remove(v T) bool {
+	n, ok := s.data[v]
+	if !ok {
+		return false
+	}
+	s.unlink(n)
+	delete(s.data, v)
+	return true
+}
+
+// unlink takes n out of the list, n is still in data.
+// It must be called with the write lock held
+func (s *linearSet[T]) unlink(n *setNode[T]) {
+	if n.pre == nil {
+		s.head = n.next
+	} else {
+		n.pre.next = n.next
+	}
+	if n.next == nil {
+		s.tail = n.pre
+	} else {
+		n.next.pre = n.pre
+	}
+	n.pre, n.next = nil, nil
+}
+
+// linkBefore puts the unlinked n before mark, or at the tail if mark is nil.
+// It must be called with the write lock held
+func (s *linearSet[T]) linkBefore(n, mark *setNode[T]) {
+	if mark == nil {
+		n.pre = s.tail
+		if s.tail == nil {
+			s.head = n
+		} else {
+			s.tail.next = n
 		}
+		s.tail = n
+		return
+	}
+	n.pre, n.next = mark.pre, mark
+	if mark.pre == nil {
+		s.head = n
+	} else {
+		mark.pre.next = n
 	}
+	mark.pre = n
+}
+
+// moveToBack moves v to the tail if it exists, and returns whether it exists.
+// It must be called with the write lock held
+func (s *linearSet[T]) moveToBack(v T) bool {
+	n, ok := s.data[v]
+	if !ok {
+		return false
+	}
+	if n != s.tail {
+		s.unlink(n)
+		s.linkBefore(n, nil)
+	}
+	return true
 }
 
 func (s *linearSet[T]) Clear() {
 	defer s.m.Unlock()
 	s.m.Lock()
 
+	s.reset()
+}
+
+// reset removes all elements, it must be called with the write lock held
+func (s *linearSet[T]) reset() {
 	s.head = nil
 	s.tail = nil
 	s.data = make(map[T]*setNode[T])
@@ -179,35 +238,58 @@ func (s *linearSet[T]) Clear() {
 
 // Length returns linearSet length
 func (s *linearSet[T]) Length() int {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
 	return len(s.data)
 }
 
 // Has returns whether v exists in linearSet
 func (s *linearSet[T]) Has(v T) bool {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
 	_, ok := s.data[v]
 	return ok
 }
 
-// Copy returns a deep copy of itself
-func (s *linearSet[T]) copy(add func(s *linearSet[T], vals ...T)) *linearSet[T] {
+// copy returns a deep copy of itself in the same order
+func (s *linearSet[T]) copy() *linearSet[T] {
 	defer s.m.RUnlock()
 	s.m.RLock()
 
-	r := newLinearSet[T](add)
-	cur := s.head
-	for cur != nil {
-		add(r, cur.val)
-		cur = cur.next
+	r := newLinearSet[T](addFifo[T])
+	for cur := s.head; cur != nil; cur = cur.next {
+		r.pushBack(cur.val)
 	}
 	return r
 }
 
+// pushBackAll appends vals to the tail in order, elements already existing are left unchanged.
+// It must be called with the write lock held
+func pushBackAll[T comparable](s *linearSet[T], vals []T) {
+	for _, v := range vals {
+		s.pushBack(v)
+	}
+}
+
+// pushFrontAll puts vals before the head, so that vals[0] becomes the head, elements already existing are left unchanged.
+// It must be called with the write lock held
+func pushFrontAll[T comparable](s *linearSet[T], vals []T) {
+	for i := len(vals) - 1; i >= 0; i-- {
+		s.pushFront(vals[i])
+	}
+}
+
 // ToList returns data slice
 func (s *linearSet[T]) ToList() []T {
+	if s == nil {
+		return nil
+	}
 	defer s.m.RUnlock()
 	s.m.RLock()
 
-	if s == nil || s.Length() == 0 {
+	if len(s.data) == 0 {
 		return nil
 	}
 
@@ -220,135 +302,267 @@ func (s *linearSet[T]) ToList() []T {
 	return r
 }
 
-// Equals returns whether linearSet s has the same members with linearSet t
-func (s *linearSet[T]) Equals(t *linearSet[T]) bool {
-	if t == nil {
-		return false
-	}
-	return reflect.DeepEqual(s.data, t.data)
+// linear is implemented by the sets built on linearSet
+type linear[T comparable] interface {
+	linear() *linearSet[T]
 }
 
-// IsSub returns if it's a part of Set t.
-// Note that it's defined that nil is sub of any linearSet
-func (s *linearSet[T]) IsSub(t *linearSet[T]) bool {
-	if t == nil {
+func (s *linearSet[T]) linear() *linearSet[T] {
+	return s
+}
+
+// isSelf returns whether t is backed by s itself
+func (s *linearSet[T]) isSelf(t ReadableSet[T]) bool {
+	l, ok := t.(linear[T])
+	return ok && l.linear() == s
+}
+
+// Equals returns whether linearSet s has the same members with set t, the order is ignored
+func (s *linearSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
 		return false
 	}
-	if s == nil || s == t {
+	if l, ok := t.(linear[T]); ok {
+		o := l.linear()
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
+
+		if len(s.data) != len(o.data) {
+			return false
+		}
+		for v := range s.data {
+			if _, ok := o.data[v]; !ok {
+				return false
+			}
+		}
 		return true
 	}
+	return equals[T](s, t)
+}
 
-	s.m.RLock()
-	t.m.RLock()
+// EqualsOrdered returns whether linearSet s has the same elements with set t in the same order,
+// elements of t are in the order of its ToList
+func (s *linearSet[T]) EqualsOrdered(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if l, ok := t.(linear[T]); ok {
+		o := l.linear()
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
+
+		a, b := s.head, o.head
+		for ; a != nil && b != nil; a, b = a.next, b.next {
+			if a.val != b.val {
+				return false
+			}
+		}
+		return a == nil && b == nil
+	}
 
+	vals := t.ToList()
 	defer s.m.RUnlock()
-	defer t.m.RUnlock()
+	s.m.RLock()
 
-	if s.Length() > t.Length() {
+	if len(vals) != len(s.data) {
 		return false
 	}
-	for k := range s.data {
-		if !t.Has(k) {
+	cur := s.head
+	for _, v := range vals {
+		if cur.val != v {
 			return false
 		}
+		cur = cur.next
 	}
 	return true
 }
 
-func (s *linearSet[T]) union(t *linearSet[T], add func(s *linearSet[T], vals ...T)) *linearSet[T] {
-	r := s.copy(add)
-	if t == nil || s == t {
-		return r
+// IsSub returns if it's a part of set t.
+// Note that it's defined that nil is sub of any linearSet
+func (s *linearSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
 	}
-	s.m.RLock()
-	t.m.RLock()
-
-	defer s.m.RUnlock()
-	defer t.m.RUnlock()
-	for _, d := range t.ToList() {
-		add(r, d)
+	if s == nil || s.isSelf(t) {
+		return true
 	}
-	return r
+	return isSub[T](s, t)
 }
 
-func (s *linearSet[T]) intersect(t *linearSet[T], add func(s *linearSet[T], vals ...T)) *linearSet[T] {
-	r := newLinearSet[T](add)
-	if s == nil || t == nil || s.Length() == 0 || t.Length() == 0 {
-		return r
-	}
-	if s == t {
-		// intersect itself
-		return s.copy(add)
+// Operations below keep the order of elements of s, and put new elements of t by push in the order of t's ToList
+
+func (s *linearSet[T]) union(t ReadableSet[T], push func(s *linearSet[T], vals []T)) *linearSet[T] {
+	if isNil(t) || s.isSelf(t) {
+		return s.copy()
 	}
+	vals := t.ToList()
+	r := s.copy()
+	push(r, vals)
+	return r
+}
 
+// filter returns a new linearSet of elements satisfying keep in the same order
+func (s *linearSet[T]) filter(keep func(v T) bool) *linearSet[T] {
+	defer s.m.RUnlock()
 	s.m.RLock()
-	t.m.RLock()
 
-	defer s.m.RUnlock()
-	defer t.m.RUnlock()
-	if s.Length() >= t.Length() {
-		for _, v := range t.ToList() {
-			if s.Has(v) {
-				add(r, v)
-			}
-		}
-	} else {
-		for _, v := range s.ToList() {
-			if t.Has(v) {
-				add(r, v)
-			}
+	r := newLinearSet[T](addFifo[T])
+	for cur := s.head; cur != nil; cur = cur.next {
+		if keep(cur.val) {
+			r.pushBack(cur.val)
 		}
 	}
 	return r
 }
 
-func (s *linearSet[T]) subtract(t *linearSet[T], add func(s *linearSet[T], vals ...T)) *linearSet[T] {
-	if t == nil || t.Length() == 0 {
-		return s.copy(add)
+func (s *linearSet[T]) intersect(t ReadableSet[T]) *linearSet[T] {
+	if isNil(t) {
+		return newLinearSet[T](addFifo[T])
+	}
+	if s.isSelf(t) {
+		// intersect itself
+		return s.copy()
 	}
+	t = lockFree(t)
+	return s.filter(t.Has)
+}
 
-	s.m.RLock()
-	t.m.RLock()
+func (s *linearSet[T]) subtract(t ReadableSet[T]) *linearSet[T] {
+	if isNil(t) {
+		return s.copy()
+	}
+	if s.isSelf(t) {
+		return newLinearSet[T](addFifo[T])
+	}
+	t = lockFree(t)
+	return s.filter(func(v T) bool {
+		return !t.Has(v)
+	})
+}
 
-	defer s.m.RUnlock()
-	defer t.m.RUnlock()
+func (s *linearSet[T]) complement(t ReadableSet[T], push func(s *linearSet[T], vals []T)) *linearSet[T] {
+	if isNil(t) {
+		return s.copy()
+	}
+	if s.isSelf(t) {
+		return newLinearSet[T](addFifo[T])
+	}
+	vals := t.ToList()
+	u := NewUnsafeSet(vals...)
+	r := s.filter(func(v T) bool {
+		return !u.Has(v)
+	})
 
-	r := s.copy(add)
-	for _, v := range s.ToList() {
-		if t.Has(v) {
-			r.Delete(v)
+	s.m.RLock()
+	news := make([]T, 0, len(vals))
+	for _, v := range vals {
+		if _, ok := s.data[v]; !ok {
+			news = append(news, v)
 		}
 	}
+	s.m.RUnlock()
+
+	push(r, news)
 	return r
 }
 
-func (s *linearSet[T]) complement(t *linearSet[T], add func(s *linearSet[T], vals ...T)) *linearSet[T] {
-	if s == nil || t == nil || s.Length() == 0 || t.Length() == 0 {
-		return s.copy(add)
+// unionWith adds elements of t by push, and returns the number of added elements
+func (s *linearSet[T]) unionWith(t ReadableSet[T], push func(s *linearSet[T], vals []T)) int {
+	if isNil(t) {
+		return 0
 	}
+	vals := t.ToList()
+	var n int
+	s.bounded(func() {
+		n = len(s.data)
+		push(s, vals)
+		n = len(s.data) - n
+	})
+	return n
+}
+
+// intersectWith deletes elements which don't exist in t, and returns the number of deleted elements
+func (s *linearSet[T]) intersectWith(t ReadableSet[T]) int {
+	t = lockFree(t)
+	defer s.m.Unlock()
+	s.m.Lock()
 
-	if s == t {
-		return newLinearSet[T](add)
+	var n int
+	for cur := s.head; cur != nil; {
+		next := cur.next
+		if t == nil || !t.Has(cur.val) {
+			s.remove(cur.val)
+			n++
+		}
+		cur = next
 	}
+	return n
+}
 
-	s.m.RLock()
-	t.m.RLock()
-	defer s.m.RUnlock()
-	defer t.m.RUnlock()
+// subtractWith deletes elements which exist in t, and returns the number of deleted elements
+func (s *linearSet[T]) subtractWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := t.ToList()
+	defer s.m.Unlock()
+	s.m.Lock()
 
-	var r = s.union(t, add)
-	if s.Length() >= t.Length() {
-		for _, v := range t.ToList() {
-			if s.Has(v) {
-				r.Delete(v)
-			}
+	var n int
+	for _, v := range vals {
+		if s.remove(v) {
+			n++
 		}
-	} else {
-		for _, v := range s.ToList() {
-			if t.Has(v) {
-				r.Delete(v)
+	}
+	return n
+}
+
+// symmetricDifferenceWith deletes elements which exist in t and adds the others of t by push,
+// and returns the number of deleted and added elements
+func (s *linearSet[T]) symmetricDifferenceWith(t ReadableSet[T], push func(s *linearSet[T], vals []T)) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := t.ToList()
+	s.bounded(func() {
+		news := make([]T, 0, len(vals))
+		for _, v := range vals {
+			if !s.remove(v) {
+				news = append(news, v)
 			}
 		}
-	}
-	return r
+		push(s, news)
+	})
+	return len(vals)
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *linearSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	return relation[T](s, t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *linearSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *linearSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *linearSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *linearSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	return disjoint[T](s, t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *linearSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
 }
@@ -0,0 +1,51 @@
+package goset
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// Locking rules of sets in this package:
+//   - a method never calls another locking method of the same set while holding its lock
+//   - a method never calls methods of another set while holding its lock, unless the other set is lock free,
+//     see lockFree
+//   - when a method needs locks of two sets, it takes them by rlockBoth in a stable global order
+
+// rlockBoth read locks a and b in the order of their addresses, and returns the function to unlock them.
+// Goroutines locking the same pair of sets always lock them in the same order, so they can't deadlock.
+// The lock is taken only once if a and b are the same
+func rlockBoth(a, b *sync.RWMutex) (unlock func()) {
+	if a == b {
+		a.RLock()
+		return a.RUnlock
+	}
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.RLock()
+	b.RLock()
+	return func() {
+		b.RUnlock()
+		a.RUnlock()
+	}
+}
+
+// lockBoth write locks a and read locks b in the order of their addresses, see rlockBoth.
+// Only the write lock is taken if a and b are the same
+func lockBoth(a, b *sync.RWMutex) (unlock func()) {
+	if a == b {
+		a.Lock()
+		return a.Unlock
+	}
+	if uintptr(unsafe.Pointer(a)) < uintptr(unsafe.Pointer(b)) {
+		a.Lock()
+		b.RLock()
+	} else {
+		b.RLock()
+		a.Lock()
+	}
+	return func() {
+		a.Unlock()
+		b.RUnlock()
+	}
+}
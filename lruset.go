@@ -0,0 +1,162 @@
+package goset
+
+import "fmt"
+
+// LRUSet is a set whose elements are ordered by access, from the least recently used to the most recently used.
+// Add, Has and Touch make elements the most recently used ones,
+// and the least recently used element is evicted when the capacity is exceeded.
+// Set operations and comparisons don't change the order of access, neither does being their operand
+type LRUSet[T comparable] struct {
+	*linearSet[T]
+}
+
+// NewLRUSet creates a new LRUSet holding at most capacity elements, vals are used in order.
+// Sets returned by Copy and set operations are unbounded.
+// It panics if capacity isn't positive
+//
+// for example:
+// var s=NewLRUSet(2, 1, 2)
+// s.Has(1)
+// s.Add(3)
+// s.ToList() returns [1,3]
+func NewLRUSet[T comparable](capacity int, vals ...T) *LRUSet[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("goset: capacity %d isn't positive", capacity))
+	}
+	s := &LRUSet[T]{newLinearSet[T](addFifo[T])}
+	s.capacity = capacity
+	s.Add(vals...)
+	return s
+}
+
+// Add adds elements as the most recently used ones, elements already existing are moved.
+// The least recently used elements are evicted if the capacity is exceeded
+func (s *LRUSet[T]) Add(vals ...T) {
+	s.bounded(func() {
+		for _, v := range vals {
+			if !s.moveToBack(v) {
+				s.pushBack(v)
+			}
+		}
+	})
+}
+
+// Has returns whether v exists in LRUSet, and makes v the most recently used one if it exists
+func (s *LRUSet[T]) Has(v T) bool {
+	return s.Touch(v)
+}
+
+// untouched returns the LRUSet without touching elements, so that it can be an operand of other sets
+func (s *LRUSet[T]) untouched() ReadableSet[T] {
+	return s.linearSet
+}
+
+// Touch makes v the most recently used one, and returns false if v doesn't exist
+func (s *LRUSet[T]) Touch(v T) bool {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.moveToBack(v)
+}
+
+// Oldest returns the least recently used element, ok is false if LRUSet is empty
+func (s *LRUSet[T]) Oldest() (v T, ok bool) {
+	return s.peekFront()
+}
+
+// Newest returns the most recently used element, ok is false if LRUSet is empty
+func (s *LRUSet[T]) Newest() (v T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	if s.tail == nil {
+		return v, false
+	}
+	return s.tail.val, true
+}
+
+// OnEvict sets the callback called with every evicted element after the lock is released,
+// so the callback can access the LRUSet
+func (s *LRUSet[T]) OnEvict(fn func(v T)) {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	s.onEvict = fn
+}
+
+// Evictions returns the number of elements evicted since LRUSet was created
+func (s *LRUSet[T]) Evictions() uint64 {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.evictions
+}
+
+// Capacity returns the max number of elements, 0 means unbounded
+func (s *LRUSet[T]) Capacity() int {
+	return s.capacity
+}
+
+// Copy returns a deep copy of itself in the same order
+func (s *LRUSet[T]) Copy() *LRUSet[T] {
+	return &LRUSet[T]{s.linearSet.copy()}
+}
+
+// Equals returns whether it has the same members with set t, the order is ignored
+func (s *LRUSet[T]) Equals(t ReadableSet[T]) bool {
+	return s.linearSet.Equals(t)
+}
+
+// EqualsOrdered returns whether it has the same elements with set t in the same order,
+// elements of t are in the order of its ToList
+func (s *LRUSet[T]) EqualsOrdered(t ReadableSet[T]) bool {
+	return s.linearSet.EqualsOrdered(t)
+}
+
+func (s *LRUSet[T]) IsSub(t ReadableSet[T]) bool {
+	return s.linearSet.IsSub(t)
+}
+
+// Union returns a new LRUSet with elements of t which don't exist in s as the most recently used ones,
+// in the order of t's ToList
+func (s *LRUSet[T]) Union(t ReadableSet[T]) *LRUSet[T] {
+	return &LRUSet[T]{s.linearSet.union(t, pushBackAll[T])}
+}
+
+// Subtract returns a new LRUSet whose elements exist in itself but don't exist in set t, in the same order
+func (s *LRUSet[T]) Subtract(t ReadableSet[T]) *LRUSet[T] {
+	return &LRUSet[T]{s.linearSet.subtract(t)}
+}
+
+// Intersect returns a new LRUSet whose elements exist in both sets, in the same order
+func (s *LRUSet[T]) Intersect(t ReadableSet[T]) *LRUSet[T] {
+	return &LRUSet[T]{s.linearSet.intersect(t)}
+}
+
+// Complement returns a new LRUSet whose elements only exist in one set,
+// elements of t are put as Union does
+func (s *LRUSet[T]) Complement(t ReadableSet[T]) *LRUSet[T] {
+	return &LRUSet[T]{s.linearSet.complement(t, pushBackAll[T])}
+}
+
+// UnionWith adds elements of set t as Union does, and returns the number of added elements.
+// The least recently used elements are evicted if the capacity is exceeded
+func (s *LRUSet[T]) UnionWith(t ReadableSet[T]) int {
+	return s.linearSet.unionWith(t, pushBackAll[T])
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *LRUSet[T]) IntersectWith(t ReadableSet[T]) int {
+	return s.linearSet.intersectWith(t)
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *LRUSet[T]) SubtractWith(t ReadableSet[T]) int {
+	return s.linearSet.subtractWith(t)
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t as Union does,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *LRUSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	return s.linearSet.symmetricDifferenceWith(t, pushBackAll[T])
+}
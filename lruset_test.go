@@ -0,0 +1,122 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLRUSet(t *testing.T) {
+	s := NewLRUSet(3, 1, 2, 3)
+	if v, ok := s.Oldest(); !ok || v != 1 {
+		t.Fatalf("s.Oldest() got unexpected %v %v", v, ok)
+	}
+	if v, ok := s.Newest(); !ok || v != 3 {
+		t.Fatalf("s.Newest() got unexpected %v %v", v, ok)
+	}
+
+	if !s.Has(1) || s.Has(9) {
+		t.Fatalf("s.Has() got unexpected result")
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{2, 3, 1}) {
+		t.Fatalf("s.Has(1) got unexpected %v", r)
+	}
+	if !s.Touch(2) || s.Touch(9) {
+		t.Fatalf("s.Touch() got unexpected result")
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{3, 1, 2}) {
+		t.Fatalf("s.Touch(2) got unexpected %v", r)
+	}
+
+	var evicted []int
+	s.OnEvict(func(v int) {
+		evicted = append(evicted, v)
+		s.Length()
+	})
+	// 1 is used again, so 3 and 2 are evicted
+	s.Add(1, 4, 5)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1, 4, 5}) || !reflect.DeepEqual(evicted, []int{3, 2}) {
+		t.Fatalf("s.Add(1, 4, 5) got unexpected %v, evicted %v", r, evicted)
+	}
+	if s.Evictions() != 2 || s.Capacity() != 3 {
+		t.Fatalf("s.Evictions() got unexpected %d", s.Evictions())
+	}
+
+	// set operations and comparisons don't change the order
+	s.IsSub(NewSet(1, 4, 5))
+	NewSet(1).Equals(s)
+	if n := s.UnionWith(NewFifoSet(4, 6)); n != 1 || !reflect.DeepEqual(s.ToList(), []int{4, 5, 6}) {
+		t.Fatalf("s.UnionWith() got unexpected %d, %v", n, s.ToList())
+	}
+	if u := s.Union(NewSet(7)); !reflect.DeepEqual(u.ToList(), []int{4, 5, 6, 7}) || u.Capacity() != 0 {
+		t.Fatalf("s.Union() got unexpected %v", u.ToList())
+	}
+
+	s.Clear()
+	if _, ok := s.Oldest(); ok {
+		t.Fatalf("s.Oldest() on empty set got unexpected true")
+	}
+	if _, ok := s.Newest(); ok {
+		t.Fatalf("s.Newest() on empty set got unexpected true")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("NewLRUSet(0) should panic")
+		}
+	}()
+	NewLRUSet[int](0)
+}
+
+func TestLRUSetOperand(t *testing.T) {
+	for _, k := range setKinds {
+		t.Run(k.name, func(t *testing.T) {
+			lru := NewLRUSet(10, 1, 2, 3)
+			s := k.new(1, 4)
+			s.Equals(lru)
+			s.IsSub(lru)
+			if r, ok := s.(relationalSet); ok {
+				r.Relation(lru)
+				r.IsDisjoint(lru)
+			}
+			if p, ok := s.(inPlaceSet); ok {
+				p.UnionWith(lru)
+				p.IntersectWith(lru)
+				p.SymmetricDifferenceWith(lru)
+				p.SubtractWith(lru)
+			}
+			if r := lru.ToList(); !reflect.DeepEqual(r, []int{1, 2, 3}) {
+				t.Fatalf("LRUSet as an operand got unexpected %v", r)
+			}
+		})
+	}
+
+	lru := NewLRUSet(10, 1, 2, 3)
+	NewSet(1, 4).Intersect(lru)
+	NewUnsafeSet(1, 4).Intersect(lru)
+	NewUnsafeSet(1, 4).Subtract(lru)
+	NewUnsafeSortedSet(1, 4).Intersect(lru)
+	NewUnsafeSortedSet(1, 4).Subtract(lru)
+	NewShardedSet(1, 4).Intersect(lru)
+	NewFifoSet(1, 4).Intersect(lru)
+	NewImmutableSet(1).Union(lru)
+	if r := lru.ToList(); !reflect.DeepEqual(r, []int{1, 2, 3}) {
+		t.Fatalf("LRUSet as an operand of set operations got unexpected %v", r)
+	}
+
+	// LRUSet wrapped by SyncSet is the receiver
+	for _, k := range setKinds {
+		t.Run("Synchronized/"+k.name, func(t *testing.T) {
+			lru := NewLRUSet(10, 1, 2, 3)
+			s := Synchronized[int](lru)
+			s.Equals(k.new(1))
+			s.IsSub(k.new(1))
+			s.Relation(k.new(1))
+			s.IsDisjoint(k.new(1))
+			s.UnionWith(k.new(1))
+			s.SymmetricDifferenceWith(k.new(2, 4))
+			if r := lru.ToList(); !reflect.DeepEqual(r, []int{1, 3, 4}) {
+				t.Fatalf("LRUSet as the receiver got unexpected %v", r)
+			}
+		})
+	}
+}
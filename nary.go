@@ -0,0 +1,174 @@
+package goset
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// naryable is implemented by sets supporting n-ary operations, such as Set, FifoSet, FiloSet and SortedSet
+type naryable[T comparable, S any] interface {
+	filterable[T, S]
+	UnionWith(t ReadableSet[T]) int
+	IntersectWith(t ReadableSet[T]) int
+}
+
+// UnionAll returns a new set of the same kind containing elements of all sets, it returns nil if no set is given.
+// SortedSets in the same order are merged in linear time, other sets keep the order of sets and their elements
+//
+// for example:
+// UnionAll[int](NewFifoSet(1, 2), NewFifoSet(3, 2), NewFifoSet(4)) returns FifoSet{1,2,3,4}
+func UnionAll[T comparable, S naryable[T, S]](sets ...S) S {
+	if len(sets) == 0 {
+		var zero S
+		return zero
+	}
+	if r, ok := mergeSortedSets[T, S](sets, 1); ok {
+		return r
+	}
+	r := sets[0].Copy()
+	for _, s := range sets[1:] {
+		r.UnionWith(s)
+	}
+	return r
+}
+
+// IntersectAll returns a new set of the same kind containing elements existing in all sets, it returns nil if no set is given.
+// Sets are intersected from the smallest one, and it stops as soon as the result is empty,
+// the elements keep the order of the smallest set if it's linear
+func IntersectAll[T comparable, S naryable[T, S]](sets ...S) S {
+	if len(sets) == 0 {
+		var zero S
+		return zero
+	}
+	sorted := make([]S, len(sets))
+	copy(sorted, sets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Length() < sorted[j].Length()
+	})
+
+	r := sorted[0].Copy()
+	for _, s := range sorted[1:] {
+		if r.Length() == 0 {
+			break
+		}
+		r.IntersectWith(s)
+	}
+	return r
+}
+
+// CountAtLeast returns a new set of the same kind containing elements existing in at least k sets,
+// it returns nil if no set is given. SortedSets in the same order are merged in linear time,
+// other sets keep the order of UnionAll
+//
+// for example:
+// CountAtLeast[int](2, NewSet(1, 2), NewSet(2, 3), NewSet(3, 4)) returns {2,3}
+func CountAtLeast[T comparable, S naryable[T, S]](k int, sets ...S) S {
+	if len(sets) == 0 {
+		var zero S
+		return zero
+	}
+	if r, ok := mergeSortedSets[T, S](sets, k); ok {
+		return r
+	}
+
+	counts := make(map[T]int)
+	for _, s := range sets {
+		for _, v := range s.ToList() {
+			counts[v]++
+		}
+	}
+	// delete elements from the union instead of adding them again, so that the order is kept
+	r := sets[0].Copy()
+	for _, s := range sets[1:] {
+		r.UnionWith(s)
+	}
+	var drop []T
+	for v, n := range counts {
+		if n < k {
+			drop = append(drop, v)
+		}
+	}
+	r.Delete(drop...)
+	return r
+}
+
+// mergeSortedSets merges sets by k-way merge if they're SortedSets in the same order,
+// and returns a new SortedSet containing elements existing in at least k sets. ok is false if sets can't be merged
+func mergeSortedSets[T comparable, S any](sets []S, k int) (r S, ok bool) {
+	first, ok := any(sets[0]).(*SortedSet[T])
+	if !ok {
+		return r, false
+	}
+	cmp := first.u.list.cmp
+	lists := make([][]T, len(sets))
+	for i, s := range sets {
+		lists[i] = any(s).(*SortedSet[T]).ToList()
+		if !isSortedBy(lists[i], cmp) {
+			// the set is ordered by another comparator
+			return r, false
+		}
+	}
+
+	b := newSkipListBuilder[T](cmp)
+	mergeSorted(lists, cmp, func(v T, count int) {
+		if count >= k {
+			b.push(v)
+		}
+	})
+	return any(&SortedSet[T]{u: UnsafeSortedSet[T]{list: b.build()}}).(S), true
+}
+
+// mergeSorted merges lists sorted by cmp, and calls fn on every distinct value in asc order
+// with the number of lists containing it
+func mergeSorted[T any](lists [][]T, cmp func(a, b T) int, fn func(v T, count int)) {
+	h := &cursorHeap[T]{cmp: cmp}
+	for _, l := range lists {
+		if len(l) > 0 {
+			h.cursors = append(h.cursors, l)
+		}
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		v := h.cursors[0][0]
+		count := 0
+		for h.Len() > 0 && cmp(h.cursors[0][0], v) == 0 {
+			count++
+			if h.cursors[0] = h.cursors[0][1:]; len(h.cursors[0]) == 0 {
+				heap.Pop(h)
+			} else {
+				heap.Fix(h, 0)
+			}
+		}
+		fn(v, count)
+	}
+}
+
+// cursorHeap is a min heap of the remaining parts of sorted lists, ordered by their first elements
+type cursorHeap[T any] struct {
+	cursors [][]T
+	cmp     func(a, b T) int
+}
+
+func (h *cursorHeap[T]) Len() int {
+	return len(h.cursors)
+}
+
+func (h *cursorHeap[T]) Less(i, j int) bool {
+	return h.cmp(h.cursors[i][0], h.cursors[j][0]) < 0
+}
+
+func (h *cursorHeap[T]) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func (h *cursorHeap[T]) Push(x any) {
+	h.cursors = append(h.cursors, x.([]T))
+}
+
+func (h *cursorHeap[T]) Pop() any {
+	n := len(h.cursors)
+	x := h.cursors[n-1]
+	h.cursors = h.cursors[:n-1]
+	return x
+}
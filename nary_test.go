@@ -0,0 +1,111 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnionAll(t *testing.T) {
+	if r := UnionAll[int, *Set[int]](); r != nil {
+		t.Fatalf("UnionAll() got unexpected %v", r.ToList())
+	}
+	if r := UnionAll[int](NewSet(1, 2), NewSet(2, 3), NewSet[int]()); !r.Equals(NewSet(1, 2, 3)) {
+		t.Fatalf("UnionAll(Set) got unexpected %v", r.ToList())
+	}
+	if r := UnionAll[int](NewFifoSet(1, 2), NewFifoSet(3, 2), NewFifoSet(4)); !reflect.DeepEqual(r.ToList(), []int{1, 2, 3, 4}) {
+		t.Fatalf("UnionAll(FifoSet) got unexpected %v", r.ToList())
+	}
+
+	a, b, c := NewSortedSet(5, 1, 3), NewSortedSet(2, 3), NewSortedSet(9, 1)
+	r := UnionAll[int](a, b, c)
+	checkSkipList(t, r.u.list)
+	if !reflect.DeepEqual(r.ToList(), []int{1, 2, 3, 5, 9}) {
+		t.Fatalf("UnionAll(SortedSet) got unexpected %v", r.ToList())
+	}
+	if !reflect.DeepEqual(a.ToList(), []int{1, 3, 5}) {
+		t.Fatalf("UnionAll(SortedSet) modified the set %v", a.ToList())
+	}
+
+	desc := NewSortedSetFunc(func(a, b int) int { return b - a }, 4, 2)
+	if r := UnionAll[int](a, desc); !reflect.DeepEqual(r.ToList(), []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("UnionAll(SortedSet in different orders) got unexpected %v", r.ToList())
+	}
+}
+
+func TestIntersectAll(t *testing.T) {
+	if r := IntersectAll[int](NewSet(1, 2, 3, 4), NewSet(2, 3, 4), NewSet(4, 3)); !r.Equals(NewSet(3, 4)) {
+		t.Fatalf("IntersectAll(Set) got unexpected %v", r.ToList())
+	}
+	if r := IntersectAll[int](NewSet(1, 2), NewSet[int](), NewSet(1)); r.Length() != 0 {
+		t.Fatalf("IntersectAll() with empty set got unexpected %v", r.ToList())
+	}
+	if r := IntersectAll[int](NewFiloSet(1, 2, 3), NewFiloSet(3, 2)); !reflect.DeepEqual(r.ToList(), []int{2, 3}) {
+		t.Fatalf("IntersectAll(FiloSet) got unexpected %v", r.ToList())
+	}
+	if r := IntersectAll[int](NewSortedSet(5, 1, 3), NewSortedSet(1, 5, 7)); !reflect.DeepEqual(r.ToList(), []int{1, 5}) {
+		t.Fatalf("IntersectAll(SortedSet) got unexpected %v", r.ToList())
+	}
+	if r := IntersectAll[int](NewSet(1)); !r.Equals(NewSet(1)) {
+		t.Fatalf("IntersectAll() with one set got unexpected %v", r.ToList())
+	}
+}
+
+func TestCountAtLeast(t *testing.T) {
+	if r := CountAtLeast[int](2, NewSet(1, 2), NewSet(2, 3), NewSet(3, 4)); !r.Equals(NewSet(2, 3)) {
+		t.Fatalf("CountAtLeast(Set) got unexpected %v", r.ToList())
+	}
+	if r := CountAtLeast[int](2, NewFifoSet(4, 1, 2), NewFifoSet(2, 3), NewFifoSet(3, 4)); !reflect.DeepEqual(r.ToList(), []int{4, 2, 3}) {
+		t.Fatalf("CountAtLeast(FifoSet) got unexpected %v", r.ToList())
+	}
+	filo := []*FiloSet[int]{NewFiloSet(1, 2, 3), NewFiloSet(5, 2, 4)}
+	if r, u := CountAtLeast[int](1, filo...), UnionAll[int](filo...); !reflect.DeepEqual(r.ToList(), u.ToList()) {
+		t.Fatalf("CountAtLeast(1, FiloSet) got unexpected %v, UnionAll got %v", r.ToList(), u.ToList())
+	}
+	if r := CountAtLeast[int](1, NewFiloSet(1, 2, 3)); !reflect.DeepEqual(r.ToList(), []int{3, 2, 1}) {
+		t.Fatalf("CountAtLeast(1, FiloSet) got unexpected %v", r.ToList())
+	}
+	if r := CountAtLeast[int](2, filo...); !reflect.DeepEqual(r.ToList(), []int{2}) {
+		t.Fatalf("CountAtLeast(2, FiloSet) got unexpected %v", r.ToList())
+	}
+
+	sets := []*SortedSet[int]{NewSortedSet(1, 2, 3), NewSortedSet(2, 3, 4), NewSortedSet(3, 4, 5)}
+	for k, want := range map[int][]int{
+		0: {1, 2, 3, 4, 5},
+		1: {1, 2, 3, 4, 5},
+		2: {2, 3, 4},
+		3: {3},
+		4: nil,
+	} {
+		r := CountAtLeast[int](k, sets...)
+		checkSkipList(t, r.u.list)
+		if !reflect.DeepEqual(r.ToList(), want) {
+			t.Fatalf("CountAtLeast(%d, SortedSet) got unexpected %v", k, r.ToList())
+		}
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	var vals, counts []int
+	lists := [][]int{{1, 4, 7}, {}, {2, 4}, {4, 7, 9}}
+	mergeSorted(lists, func(a, b int) int { return a - b }, func(v, count int) {
+		vals = append(vals, v)
+		counts = append(counts, count)
+	})
+	if !reflect.DeepEqual(vals, []int{1, 2, 4, 7, 9}) || !reflect.DeepEqual(counts, []int{1, 1, 3, 2, 1}) {
+		t.Fatalf("mergeSorted() got unexpected %v with counts %v", vals, counts)
+	}
+}
+
+func BenchmarkUnionAll(b *testing.B) {
+	sets := make([]*SortedSet[int], 50)
+	for i := range sets {
+		sets[i] = NewSortedSet[int]()
+		for j := 0; j < 200; j++ {
+			sets[i].Add(i*100 + j)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UnionAll[int](sets...)
+	}
+}
@@ -0,0 +1,95 @@
+package goset
+
+import (
+	"testing"
+	"time"
+)
+
+// nilOperands are typed nil sets of every kind, which are treated like nil
+var nilOperands = []ReadableSet[int]{
+	nil,
+	(*Set[int])(nil),
+	(*FifoSet[int])(nil),
+	(*FiloSet[int])(nil),
+	(*LRUSet[int])(nil),
+	(*SortedSet[int])(nil),
+	(*ShardedSet[int])(nil),
+	(*UnsafeSet[int])(nil),
+	(*UnsafeSortedSet[int])(nil),
+	(*SyncSet[int])(nil),
+	(*TTLSet[int])(nil),
+	(*ImmutableSet[int])(nil),
+	(*SetSnapshot[int])(nil),
+}
+
+// binarySet is implemented by sets whose set operations return S
+type binarySet[S ReadableSet[int]] interface {
+	ReadableSet[int]
+	Union(t ReadableSet[int]) S
+	Intersect(t ReadableSet[int]) S
+	Subtract(t ReadableSet[int]) S
+	Complement(t ReadableSet[int]) S
+}
+
+func checkNilAlgebra[S ReadableSet[int]](t *testing.T, name string, s binarySet[S]) {
+	for _, o := range nilOperands {
+		if r := s.Union(o); !r.Equals(NewSet(1, 2)) {
+			t.Fatalf("%s.Union(%T) got unexpected %v", name, o, r.ToList())
+		}
+		if r := s.Intersect(o); r.Length() != 0 {
+			t.Fatalf("%s.Intersect(%T) got unexpected %v", name, o, r.ToList())
+		}
+		if r := s.Subtract(o); !r.Equals(NewSet(1, 2)) {
+			t.Fatalf("%s.Subtract(%T) got unexpected %v", name, o, r.ToList())
+		}
+		if r := s.Complement(o); !r.Equals(NewSet(1, 2)) {
+			t.Fatalf("%s.Complement(%T) got unexpected %v", name, o, r.ToList())
+		}
+	}
+}
+
+func TestNilOperand(t *testing.T) {
+	for _, k := range setKinds {
+		t.Run(k.name, func(t *testing.T) {
+			for _, o := range nilOperands {
+				s := k.new(1, 2)
+				if s.Equals(o) || s.IsSub(o) {
+					t.Fatalf("Equals(%T) or IsSub(%T) got unexpected true", o, o)
+				}
+				if r, ok := s.(relationalSet); ok {
+					if r.Relation(o) != RelationSuperset || !r.IsDisjoint(o) || r.Intersects(o) {
+						t.Fatalf("Relation(%T) got unexpected %v", o, r.Relation(o))
+					}
+				}
+				if p, ok := s.(inPlaceSet); ok {
+					if p.UnionWith(o) != 0 || p.SubtractWith(o) != 0 || p.SymmetricDifferenceWith(o) != 0 {
+						t.Fatalf("in-place operations with %T got unexpected %v", o, p.ToList())
+					}
+					if n := p.IntersectWith(o); n != 2 || p.Length() != 0 {
+						t.Fatalf("IntersectWith(%T) got unexpected %d, %v", o, n, p.ToList())
+					}
+				}
+			}
+		})
+	}
+
+	checkNilAlgebra[*Set[int]](t, "Set", NewSet(1, 2))
+	checkNilAlgebra[*FifoSet[int]](t, "FifoSet", NewFifoSet(1, 2))
+	checkNilAlgebra[*FiloSet[int]](t, "FiloSet", NewFiloSet(1, 2))
+	checkNilAlgebra[*LRUSet[int]](t, "LRUSet", NewLRUSet(10, 1, 2))
+	checkNilAlgebra[*SortedSet[int]](t, "SortedSet", NewSortedSet(1, 2))
+	checkNilAlgebra[*ShardedSet[int]](t, "ShardedSet", NewShardedSet(1, 2))
+	checkNilAlgebra[*UnsafeSet[int]](t, "UnsafeSet", NewUnsafeSet(1, 2))
+	checkNilAlgebra[*UnsafeSortedSet[int]](t, "UnsafeSortedSet", NewUnsafeSortedSet(1, 2))
+	checkNilAlgebra[*SyncSet[int]](t, "SyncSet", Synchronized[int](NewUnsafeSet(1, 2)))
+	checkNilAlgebra[*Set[int]](t, "TTLSet", NewTTLSet(time.Hour, 1, 2))
+
+	for _, o := range nilOperands {
+		if r := NewImmutableSet(1, 2).Union(o); !r.Equals(NewSet(1, 2)) {
+			t.Fatalf("ImmutableSet.Union(%T) got unexpected %v", o, r.ToList())
+		}
+		if NewSortedSet(1, 2).EqualsOrdered(o) || NewFifoSet(1, 2).EqualsOrdered(o) {
+			t.Fatalf("EqualsOrdered(%T) got unexpected true", o)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package goset
+
+// SetRelation is the relation between two sets, it's returned by Relation
+type SetRelation int
+
+const (
+	// RelationEqual means both sets have the same members, including both being empty
+	RelationEqual SetRelation = iota
+	// RelationSubset means the set is a proper subset of the other one, an empty set is a proper subset of any non-empty set
+	RelationSubset
+	// RelationSuperset means the set is a proper superset of the other one
+	RelationSuperset
+	// RelationDisjoint means both sets are non-empty and have no common member
+	RelationDisjoint
+	// RelationOverlapping means both sets have common members, and each one has members the other doesn't have
+	RelationOverlapping
+)
+
+func (r SetRelation) String() string {
+	switch r {
+	case RelationEqual:
+		return "equal"
+	case RelationSubset:
+		return "subset"
+	case RelationSuperset:
+		return "superset"
+	case RelationDisjoint:
+		return "disjoint"
+	case RelationOverlapping:
+		return "overlapping"
+	default:
+		return "unknown"
+	}
+}
+
+// relation returns the relation between s and t, nil t is treated as an empty set.
+// It visits elements of the smaller set once and stops as soon as the sets are known to overlap
+func relation[T comparable](s, t ReadableSet[T]) SetRelation {
+	ns, nt := s.Length(), 0
+	if !isNil(t) {
+		nt = t.Length()
+	}
+	switch {
+	case ns == 0 && nt == 0:
+		return RelationEqual
+	case ns == 0:
+		return RelationSubset
+	case nt == 0:
+		return RelationSuperset
+	}
+
+	// neither set is changed by Has, even if s is an LRUSet wrapped by SyncSet
+	small, large := untouched(s), untouched(t)
+	if ns > nt {
+		small, large = large, small
+	}
+	var hit, miss bool
+	for _, v := range small.ToList() {
+		if large.Has(v) {
+			hit = true
+		} else {
+			miss = true
+		}
+		if hit && miss {
+			return RelationOverlapping
+		}
+	}
+	switch {
+	case !hit:
+		return RelationDisjoint
+	case ns == nt:
+		return RelationEqual
+	case ns > nt:
+		return RelationSuperset
+	default:
+		return RelationSubset
+	}
+}
+
+// disjoint returns whether s and t have no common member, nil t is treated as an empty set
+func disjoint[T comparable](s, t ReadableSet[T]) bool {
+	if isNil(t) {
+		return true
+	}
+	small, large := untouched(s), untouched(t)
+	if s.Length() > t.Length() {
+		small, large = large, small
+	}
+	for _, v := range small.ToList() {
+		if large.Has(v) {
+			return false
+		}
+	}
+	return true
+}
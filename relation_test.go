@@ -0,0 +1,84 @@
+package goset
+
+import "testing"
+
+// relationalSet is implemented by sets supporting relational predicates
+type relationalSet interface {
+	ReadableSet[int]
+	Relation(t ReadableSet[int]) SetRelation
+	IsSuperset(t ReadableSet[int]) bool
+	IsProperSub(t ReadableSet[int]) bool
+	IsProperSuperset(t ReadableSet[int]) bool
+	IsDisjoint(t ReadableSet[int]) bool
+	Intersects(t ReadableSet[int]) bool
+}
+
+func TestRelation(t *testing.T) {
+	cases := []struct {
+		s, t []int
+		want SetRelation
+	}{
+		{nil, nil, RelationEqual},
+		{[]int{1, 2}, []int{2, 1}, RelationEqual},
+		{nil, []int{1}, RelationSubset},
+		{[]int{1}, []int{1, 2}, RelationSubset},
+		{[]int{1}, nil, RelationSuperset},
+		{[]int{1, 2, 3}, []int{3, 1}, RelationSuperset},
+		{[]int{1, 2}, []int{3, 4, 5}, RelationDisjoint},
+		{[]int{1, 2}, []int{2, 3}, RelationOverlapping},
+		{[]int{1, 2, 3}, []int{3, 4}, RelationOverlapping},
+	}
+	for _, k := range setKinds {
+		if _, ok := k.new().(relationalSet); !ok {
+			continue
+		}
+		newSet := func(vals ...int) relationalSet { return k.new(vals...).(relationalSet) }
+		t.Run(k.name, func(t *testing.T) {
+			for _, c := range cases {
+				for on, o := range map[string]ReadableSet[int]{"same": newSet(c.t...), "Set": NewSet(c.t...)} {
+					s := newSet(c.s...)
+					if r := s.Relation(o); r != c.want {
+						t.Fatalf("%v.Relation(%s %v) got unexpected %v", c.s, on, c.t, r)
+					}
+					superset := c.want == RelationEqual || c.want == RelationSuperset
+					if s.IsSuperset(o) != superset {
+						t.Fatalf("%v.IsSuperset(%s %v) got unexpected %v", c.s, on, c.t, !superset)
+					}
+					if s.IsProperSub(o) != (c.want == RelationSubset) {
+						t.Fatalf("%v.IsProperSub(%s %v) got unexpected result", c.s, on, c.t)
+					}
+					if s.IsProperSuperset(o) != (c.want == RelationSuperset) {
+						t.Fatalf("%v.IsProperSuperset(%s %v) got unexpected result", c.s, on, c.t)
+					}
+					disjoint := len(c.s) == 0 || len(c.t) == 0 || c.want == RelationDisjoint
+					if s.IsDisjoint(o) != disjoint || s.Intersects(o) == disjoint {
+						t.Fatalf("%v.IsDisjoint(%s %v) got unexpected %v", c.s, on, c.t, !disjoint)
+					}
+				}
+			}
+
+			s := newSet(1, 2)
+			if s.Relation(s) != RelationEqual || !s.IsSuperset(s) || s.IsProperSub(s) || s.IsDisjoint(s) {
+				t.Fatalf("relation with itself got unexpected %v", s.Relation(s))
+			}
+			if s.Relation(nil) != RelationSuperset || !s.IsDisjoint(nil) {
+				t.Fatalf("relation with nil got unexpected %v", s.Relation(nil))
+			}
+		})
+	}
+}
+
+func TestSetRelationString(t *testing.T) {
+	for r, want := range map[SetRelation]string{
+		RelationEqual:       "equal",
+		RelationSubset:      "subset",
+		RelationSuperset:    "superset",
+		RelationDisjoint:    "disjoint",
+		RelationOverlapping: "overlapping",
+		SetRelation(-1):     "unknown",
+	} {
+		if r.String() != want {
+			t.Fatalf("SetRelation(%d).String() got unexpected %s", int(r), r.String())
+		}
+	}
+}
@@ -1,18 +1,18 @@
 package goset
 
-import (
-	"reflect"
-	"sync"
-)
+import "sync"
 
+// Set is a goroutine safe set, see UnsafeSet for the version without locks
 type Set[T comparable] struct {
-	m    sync.RWMutex
-	data map[T]struct{}
+	m sync.RWMutex
+	u UnsafeSet[T]
+	// shared is whether u.data is shared with snapshots, then it must be copied before being modified in place
+	shared bool
 }
 
 // NewSet creates a new Set
 func NewSet[T comparable](v ...T) *Set[T] {
-	s := &Set[T]{data: map[T]struct{}{}}
+	s := &Set[T]{u: UnsafeSet[T]{data: make(map[T]struct{})}}
 	if len(v) > 0 {
 		s.Add(v...)
 	}
@@ -24,12 +24,8 @@ func (s *Set[T]) Add(v ...T) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-	for _, ele := range v {
-		s.data[ele] = struct{}{}
-	}
+	s.own()
+	s.u.Add(v...)
 }
 
 // Delete delete elements
@@ -37,12 +33,8 @@ func (s *Set[T]) Delete(v ...T) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-	for _, ele := range v {
-		delete(s.data, ele)
-	}
+	s.own()
+	s.u.Delete(v...)
 }
 
 // Clear clears all elements
@@ -50,7 +42,17 @@ func (s *Set[T]) Clear() {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	s.data = make(map[T]struct{})
+	// Clear replaces data by a new map, so it doesn't need to be copied
+	s.u.Clear()
+	s.shared = false
+}
+
+// own copies data if it's shared with snapshots, it must be called with the write lock held
+func (s *Set[T]) own() {
+	if s.shared {
+		s.u = *s.u.Copy()
+		s.shared = false
+	}
 }
 
 // Copy returns a deep copy of itself
@@ -58,20 +60,15 @@ func (s *Set[T]) Copy() *Set[T] {
 	s.m.RLock()
 	defer s.m.RUnlock()
 
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-
-	data := make(map[T]struct{})
-	for v := range s.data {
-		data[v] = struct{}{}
-	}
-	return &Set[T]{data: data}
+	return &Set[T]{u: *s.u.Copy()}
 }
 
 // Length returns Set length
 func (s *Set[T]) Length() int {
-	return len(s.data)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return s.u.Length()
 }
 
 // Has returns whether v exists in Set
@@ -79,11 +76,7 @@ func (s *Set[T]) Has(v T) bool {
 	s.m.RLock()
 	defer s.m.RUnlock()
 
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-	_, ok := s.data[v]
-	return ok
+	return s.u.Has(v)
 }
 
 // ToList returns data slice
@@ -91,207 +84,219 @@ func (s *Set[T]) ToList() []T {
 	s.m.RLock()
 	defer s.m.RUnlock()
 
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
+	return s.u.ToList()
+}
 
-	var data = make([]T, s.Length())
-	var i int
-	for d := range s.data {
-		data[i] = d
-		i++
+// Equals returns whether Set s has the same members with set t
+func (s *Set[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
 	}
-	return data
-}
+	if o, ok := t.(*Set[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
 
-// Equals returns whether Set s has the same members with Set t
-func (s *Set[T]) Equals(t *Set[T]) bool {
+		return s.u.Equals(&o.u)
+	}
+	t = lockFree(t)
 	s.m.RLock()
 	defer s.m.RUnlock()
 
-	if t == nil {
-		return false
-	}
-	return reflect.DeepEqual(s.data, t.data)
+	return s.u.Equals(t)
 }
 
-// IsSub returns whether it's a part of Set t
-func (s *Set[T]) IsSub(t *Set[T]) bool {
-	if t == nil {
+// IsSub returns whether it's a part of set t
+func (s *Set[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
 		return false
 	}
-	if s == t {
-		// compare with itself
-		return true
-	}
+	if o, ok := t.(*Set[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
 
+		return s.u.IsSub(&o.u)
+	}
+	t = lockFree(t)
 	s.m.RLock()
-	t.m.RLock()
-
 	defer s.m.RUnlock()
-	defer t.m.RUnlock()
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-	if t.data == nil {
-		t.data = make(map[T]struct{})
-	}
 
-	if s.Length() > t.Length() {
-		return false
-	}
-	for v := range s.data {
-		if !t.Has(v) {
-			return false
-		}
-	}
-	return true
+	return s.u.IsSub(t)
 }
 
-// Union unions with Set t and returns a new Set
+// Union unions with set t and returns a new Set
 //
 // for example:
 // var a=NewSet(1,2,3)
 // var b=NewSet(2,3,4)
 // a.Union(b) returns {1,2,3,4}
-func (s *Set[T]) Union(t *Set[T]) *Set[T] {
-	var r = s.Copy()
-	if t == nil || s == t {
-		return r
+func (s *Set[T]) Union(t ReadableSet[T]) *Set[T] {
+	if o, ok := t.(*Set[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
+
+		return &Set[T]{u: *s.u.Union(&o.u)}
 	}
+	t = lockFree(t)
 	s.m.RLock()
-	t.m.RLock()
-
 	defer s.m.RUnlock()
-	defer t.m.RUnlock()
 
-	r.Add(t.ToList()...)
-	return r
+	return &Set[T]{u: *s.u.Union(t)}
 }
 
-// Intersect returns a new Set Whose elements exist in both Set
+// Intersect returns a new Set Whose elements exist in both sets
 //
 // for example:
 // var a=NewSet(1,2,3)
 // var b=NewSet(2,3,4)
 // a.Intersect(b) returns {2,3}
-func (s *Set[T]) Intersect(t *Set[T]) *Set[T] {
-	var r = NewSet[T]()
-	if t == nil || s.Length() == 0 || t.Length() == 0 {
-		return r
-	}
-	if s == t {
-		// intersect itself
-		return s.Copy()
-	}
+func (s *Set[T]) Intersect(t ReadableSet[T]) *Set[T] {
+	if o, ok := t.(*Set[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
 
+		return &Set[T]{u: *s.u.Intersect(&o.u)}
+	}
+	t = lockFree(t)
 	s.m.RLock()
-	t.m.RLock()
-
 	defer s.m.RUnlock()
-	defer t.m.RUnlock()
-
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-	if t.data == nil {
-		t.data = make(map[T]struct{})
-	}
 
-	if s.Length() >= t.Length() {
-		for v := range t.data {
-			if s.Has(v) {
-				r.Add(v)
-			}
-		}
-	} else {
-		for v := range s.data {
-			if t.Has(v) {
-				r.Add(v)
-			}
-		}
-	}
-	return r
+	return &Set[T]{u: *s.u.Intersect(t)}
 }
 
-// Subtract returns a new Set Whose elements exist in itself but don't exist in Set t
+// Subtract returns a new Set Whose elements exist in itself but don't exist in set t
 //
 // for example:
 // var a=NewSet(1,2,3)
 // var b=NewSet(2,3,4)
 // a.Subtract(b) returns {1}
-func (s *Set[T]) Subtract(t *Set[T]) *Set[T] {
-	if t == nil || t.Length() == 0 {
-		return s.Copy()
-	}
-	var r = NewSet[T]()
-	if s == t {
-		// subtract itself
-		return r
-	}
+func (s *Set[T]) Subtract(t ReadableSet[T]) *Set[T] {
+	if o, ok := t.(*Set[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
 
+		return &Set[T]{u: *s.u.Subtract(&o.u)}
+	}
+	t = lockFree(t)
 	s.m.RLock()
-	t.m.RLock()
-
 	defer s.m.RUnlock()
-	defer t.m.RUnlock()
-
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-	if t.data == nil {
-		t.data = make(map[T]struct{})
-	}
 
-	for v := range s.data {
-		if !t.Has(v) {
-			r.Add(v)
-		}
-	}
-	return r
+	return &Set[T]{u: *s.u.Subtract(t)}
 }
 
-// Complement returns a new Set Whose elements only exists in one Set
+// Complement returns a new Set Whose elements only exists in one set
 //
 // for example:
 // var a=NewSet(1,2,3)
 // var b=NewSet(2,3,4)
 // a.Complement(b) returns {1,4}
-func (s *Set[T]) Complement(t *Set[T]) *Set[T] {
-	if t == nil || s.Length() == 0 || t.Length() == 0 {
-		return s.Copy()
-	}
+func (s *Set[T]) Complement(t ReadableSet[T]) *Set[T] {
+	if o, ok := t.(*Set[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
 
-	if s == t {
-		return NewSet[T]()
+		return &Set[T]{u: *s.u.Complement(&o.u)}
 	}
-
+	t = lockFree(t)
 	s.m.RLock()
-	t.m.RLock()
-
 	defer s.m.RUnlock()
-	defer t.m.RUnlock()
 
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
-	if t.data == nil {
-		t.data = make(map[T]struct{})
+	return &Set[T]{u: *s.u.Complement(t)}
+}
+
+// rlock read locks s and t if t is a Set, otherwise it read locks s and replaces t with its lock free version.
+// It returns the function to unlock
+func (s *Set[T]) rlock(t *ReadableSet[T]) (unlock func()) {
+	if o, ok := (*t).(*Set[T]); ok && o != nil {
+		*t = &o.u
+		return rlockBoth(&s.m, &o.m)
 	}
+	*t = lockFree(*t)
+	s.m.RLock()
+	return s.m.RUnlock
+}
 
-	var r = s.Union(t)
-	if s.Length() >= t.Length() {
-		for v := range t.data {
-			if s.Has(v) {
-				r.Delete(v)
-			}
-		}
-	} else {
-		for v := range s.data {
-			if t.Has(v) {
-				r.Delete(v)
-			}
-		}
+// lock write locks s and read locks t if t is a Set, otherwise it write locks s and replaces t with its lock free version.
+// It returns the function to unlock
+func (s *Set[T]) lock(t *ReadableSet[T]) (unlock func()) {
+	if o, ok := (*t).(*Set[T]); ok && o != nil {
+		*t = &o.u
+		return lockBoth(&s.m, &o.m)
 	}
-	return r
+	*t = lockFree(*t)
+	s.m.Lock()
+	return s.m.Unlock
+}
+
+// UnionWith adds elements of set t, and returns the number of added elements
+func (s *Set[T]) UnionWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	s.own()
+	return s.u.UnionWith(t)
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *Set[T]) IntersectWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	s.own()
+	return s.u.IntersectWith(t)
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *Set[T]) SubtractWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	s.own()
+	return s.u.SubtractWith(t)
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *Set[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	s.own()
+	return s.u.SymmetricDifferenceWith(t)
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *Set[T]) Relation(t ReadableSet[T]) SetRelation {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return s.u.Relation(t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *Set[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *Set[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *Set[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *Set[T]) IsDisjoint(t ReadableSet[T]) bool {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return s.u.IsDisjoint(t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *Set[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
 }
@@ -0,0 +1,365 @@
+package goset
+
+import (
+	"hash/maphash"
+	"runtime"
+	"sync"
+)
+
+// shard is a part of ShardedSet guarded by its own lock
+type shard[T comparable] struct {
+	m    sync.RWMutex
+	data map[T]struct{}
+	// pad to a cache line, so that locking a shard doesn't invalidate its neighbours in cpu caches
+	_ [32]byte
+}
+
+// ShardedSet is a set whose elements are hashed across shards locked independently,
+// it's faster than Set when lots of goroutines access it concurrently.
+// Operations on all elements, such as Length and ToList, lock all shards so that they see a consistent snapshot
+type ShardedSet[T comparable] struct {
+	seed   maphash.Seed
+	shards []shard[T]
+}
+
+// NewShardedSet creates a new ShardedSet, whose number of shards is decided by GOMAXPROCS
+func NewShardedSet[T comparable](vals ...T) *ShardedSet[T] {
+	return NewShardedSetN[T](runtime.GOMAXPROCS(0)*4, vals...)
+}
+
+// NewShardedSetN creates a new ShardedSet with n shards, n is rounded up to a power of 2
+func NewShardedSetN[T comparable](n int, vals ...T) *ShardedSet[T] {
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	s := (&ShardedSet[T]{seed: maphash.MakeSeed(), shards: make([]shard[T], size)}).empty()
+	s.Add(vals...)
+	return s
+}
+
+// empty returns a new empty ShardedSet with the same seed and number of shards
+func (s *ShardedSet[T]) empty() *ShardedSet[T] {
+	r := &ShardedSet[T]{
+		seed:   s.seed,
+		shards: make([]shard[T], len(s.shards)),
+	}
+	for i := range r.shards {
+		r.shards[i].data = make(map[T]struct{})
+	}
+	return r
+}
+
+func (s *ShardedSet[T]) shardOf(v T) *shard[T] {
+	return &s.shards[hashOf(s.seed, v)&uint64(len(s.shards)-1)]
+}
+
+// lockAll locks all shards in order
+func (s *ShardedSet[T]) lockAll() {
+	for i := range s.shards {
+		s.shards[i].m.Lock()
+	}
+}
+
+func (s *ShardedSet[T]) unlockAll() {
+	for i := range s.shards {
+		s.shards[i].m.Unlock()
+	}
+}
+
+// rlockAll read locks all shards in order
+func (s *ShardedSet[T]) rlockAll() {
+	for i := range s.shards {
+		s.shards[i].m.RLock()
+	}
+}
+
+func (s *ShardedSet[T]) runlockAll() {
+	for i := range s.shards {
+		s.shards[i].m.RUnlock()
+	}
+}
+
+// Add adds elements
+func (s *ShardedSet[T]) Add(vals ...T) {
+	for _, v := range vals {
+		sh := s.shardOf(v)
+		sh.m.Lock()
+		sh.data[v] = struct{}{}
+		sh.m.Unlock()
+	}
+}
+
+// Delete deletes elements
+func (s *ShardedSet[T]) Delete(vals ...T) {
+	for _, v := range vals {
+		sh := s.shardOf(v)
+		sh.m.Lock()
+		delete(sh.data, v)
+		sh.m.Unlock()
+	}
+}
+
+// Clear clears all elements
+func (s *ShardedSet[T]) Clear() {
+	s.lockAll()
+	defer s.unlockAll()
+
+	for i := range s.shards {
+		s.shards[i].data = make(map[T]struct{})
+	}
+}
+
+// Copy returns a deep copy of itself
+func (s *ShardedSet[T]) Copy() *ShardedSet[T] {
+	s.rlockAll()
+	defer s.runlockAll()
+
+	r := s.empty()
+	for i := range s.shards {
+		for v := range s.shards[i].data {
+			r.shards[i].data[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Length returns ShardedSet length
+func (s *ShardedSet[T]) Length() int {
+	s.rlockAll()
+	defer s.runlockAll()
+
+	var n int
+	for i := range s.shards {
+		n += len(s.shards[i].data)
+	}
+	return n
+}
+
+// Has returns whether v exists in ShardedSet
+func (s *ShardedSet[T]) Has(v T) bool {
+	sh := s.shardOf(v)
+	sh.m.RLock()
+	defer sh.m.RUnlock()
+
+	_, ok := sh.data[v]
+	return ok
+}
+
+// ToList returns data slice
+func (s *ShardedSet[T]) ToList() []T {
+	s.rlockAll()
+	defer s.runlockAll()
+
+	var n int
+	for i := range s.shards {
+		n += len(s.shards[i].data)
+	}
+	r := make([]T, 0, n)
+	for i := range s.shards {
+		for v := range s.shards[i].data {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+// Equals returns whether ShardedSet s has the same members with set t
+func (s *ShardedSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*ShardedSet[T]); ok && s == o {
+		return true
+	}
+	return equals[T](s, t)
+}
+
+// IsSub returns whether it's a part of set t
+func (s *ShardedSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*ShardedSet[T]); ok && s == o {
+		return true
+	}
+	return isSub[T](s, t)
+}
+
+// Union unions with set t and returns a new ShardedSet
+func (s *ShardedSet[T]) Union(t ReadableSet[T]) *ShardedSet[T] {
+	r := s.Copy()
+	if isNil(t) {
+		return r
+	}
+	r.Add(t.ToList()...)
+	return r
+}
+
+// Intersect returns a new ShardedSet whose elements exist in both sets
+func (s *ShardedSet[T]) Intersect(t ReadableSet[T]) *ShardedSet[T] {
+	if o, ok := t.(*ShardedSet[T]); ok && s == o {
+		// intersect itself
+		return s.Copy()
+	}
+	r := s.empty()
+	if isNil(t) {
+		return r
+	}
+	t = untouched(t)
+
+	if s.Length() >= t.Length() {
+		for _, v := range t.ToList() {
+			if s.Has(v) {
+				r.Add(v)
+			}
+		}
+	} else {
+		for _, v := range s.ToList() {
+			if t.Has(v) {
+				r.Add(v)
+			}
+		}
+	}
+	return r
+}
+
+// Subtract returns a new ShardedSet whose elements exist in itself but don't exist in set t
+func (s *ShardedSet[T]) Subtract(t ReadableSet[T]) *ShardedSet[T] {
+	if o, ok := t.(*ShardedSet[T]); ok && s == o {
+		// subtract itself
+		return s.empty()
+	}
+	r := s.Copy()
+	if isNil(t) {
+		return r
+	}
+	r.Delete(t.ToList()...)
+	return r
+}
+
+// Complement returns a new ShardedSet whose elements only exist in one set
+func (s *ShardedSet[T]) Complement(t ReadableSet[T]) *ShardedSet[T] {
+	if o, ok := t.(*ShardedSet[T]); ok && s == o {
+		return s.empty()
+	}
+	r := s.Union(t)
+	if isNil(t) {
+		return r
+	}
+	r.Delete(s.Intersect(t).ToList()...)
+	return r
+}
+
+// UnionWith adds elements of set t, and returns the number of added elements
+func (s *ShardedSet[T]) UnionWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := t.ToList()
+	s.lockAll()
+	defer s.unlockAll()
+
+	var n int
+	for _, v := range vals {
+		if sh := s.shardOf(v); !sh.has(v) {
+			sh.data[v] = struct{}{}
+			n++
+		}
+	}
+	return n
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *ShardedSet[T]) IntersectWith(t ReadableSet[T]) int {
+	t = lockFree(t)
+	s.lockAll()
+	defer s.unlockAll()
+
+	var n int
+	for i := range s.shards {
+		for v := range s.shards[i].data {
+			if t == nil || !t.Has(v) {
+				delete(s.shards[i].data, v)
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *ShardedSet[T]) SubtractWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := t.ToList()
+	s.lockAll()
+	defer s.unlockAll()
+
+	var n int
+	for _, v := range vals {
+		if sh := s.shardOf(v); sh.has(v) {
+			delete(sh.data, v)
+			n++
+		}
+	}
+	return n
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *ShardedSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := t.ToList()
+	s.lockAll()
+	defer s.unlockAll()
+
+	for _, v := range vals {
+		if sh := s.shardOf(v); sh.has(v) {
+			delete(sh.data, v)
+		} else {
+			sh.data[v] = struct{}{}
+		}
+	}
+	return len(vals)
+}
+
+// has returns whether v exists in the shard, the lock must be held
+func (sh *shard[T]) has(v T) bool {
+	_, ok := sh.data[v]
+	return ok
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *ShardedSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	return relation[T](s, t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *ShardedSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *ShardedSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *ShardedSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *ShardedSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	return disjoint[T](s, t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *ShardedSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
+}
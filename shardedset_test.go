@@ -0,0 +1,103 @@
+package goset
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestShardedSet(t *testing.T) {
+	s := NewShardedSetN(8, 1, -1, 5)
+	if r := len(s.shards); r != 8 {
+		t.Fatalf("NewShardedSetN(8) got unexpected %d shards", r)
+	}
+	if r := s.Length(); r != 3 {
+		t.Fatalf("s.Length() got unexpected %d", r)
+	}
+	if !s.Has(-1) || s.Has(2) {
+		t.Fatalf("s.Has() got unexpected result")
+	}
+	if r := s.Union(NewSet(10, 45, 3)); !r.Equals(NewSet(1, -1, 5, 10, 45, 3)) {
+		t.Fatalf("s.Union() got unexpected %v", r.ToList())
+	}
+	if r := s.Intersect(NewShardedSet(1, 2)); !r.Equals(NewSet(1)) {
+		t.Fatalf("s.Intersect() got unexpected %v", r.ToList())
+	}
+	if r := s.Subtract(NewFifoSet(1)); !r.Equals(NewSet(-1, 5)) {
+		t.Fatalf("s.Subtract() got unexpected %v", r.ToList())
+	}
+	if r := s.Complement(NewSortedSet(1, 2)); !r.Equals(NewSet(-1, 5, 2)) {
+		t.Fatalf("s.Complement() got unexpected %v", r.ToList())
+	}
+	if r := s.Complement(s); r.Length() != 0 {
+		t.Fatalf("s.Complement(s) got unexpected %v", r.ToList())
+	}
+	if !NewShardedSet(1).IsSub(s) || s.IsSub(NewSet(1)) {
+		t.Fatalf("IsSub() got unexpected result")
+	}
+	if r := s.Copy(); !r.Equals(s) {
+		t.Fatalf("s.Copy() got unexpected %v", r.ToList())
+	}
+	s.Delete(1, -1)
+	if !s.Equals(NewSet(5)) {
+		t.Fatalf("s.Delete(1, -1) got unexpected %v", s.ToList())
+	}
+	s.Clear()
+	if s.Length() != 0 {
+		t.Fatalf("s.Clear() got unexpected %v", s.ToList())
+	}
+}
+
+func TestShardedSetConcurrent(t *testing.T) {
+	s := NewShardedSet[string]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				s.Add(strconv.Itoa(g*1000 + i))
+				s.Has(strconv.Itoa(i))
+				if i%10 == 0 {
+					s.Length()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	r := s.ToList()
+	sort.Slice(r, func(i, j int) bool {
+		x, _ := strconv.Atoi(r[i])
+		y, _ := strconv.Atoi(r[j])
+		return x < y
+	})
+	if len(r) != 8000 || r[0] != "0" || r[7999] != "7999" {
+		t.Fatalf("s.ToList() got unexpected %d elements", len(r))
+	}
+}
+
+func BenchmarkShardedSetParallel(b *testing.B) {
+	const n = 1 << 16
+	// 1 Add every 10 operations
+	run := func(b *testing.B, s Interface[int]) {
+		b.RunParallel(func(pb *testing.PB) {
+			var i int
+			for pb.Next() {
+				if i%10 == 0 {
+					s.Add(i % n)
+				} else {
+					s.Has(i % n)
+				}
+				i++
+			}
+		})
+	}
+	b.Run("Set", func(b *testing.B) {
+		run(b, NewSet[int]())
+	})
+	b.Run("ShardedSet", func(b *testing.B) {
+		run(b, NewShardedSet[int]())
+	})
+}
@@ -0,0 +1,354 @@
+package goset
+
+import "math/rand"
+
+const (
+	// skipListMaxLevel is enough for 4^32 elements
+	skipListMaxLevel = 32
+	// skipListP is the reciprocal of the probability that a node is promoted to the next level
+	skipListP = 4
+)
+
+type skipLevel[T any] struct {
+	next *skipNode[T]
+	// span is the number of nodes stepped over by next, or the distance to the end if next is nil
+	span int
+}
+
+type skipNode[T any] struct {
+	val    T
+	pre    *skipNode[T]
+	levels []skipLevel[T]
+}
+
+// skipList is an indexable skip list whose values are unique and stored in asc order of cmp
+type skipList[T any] struct {
+	head   *skipNode[T]
+	tail   *skipNode[T]
+	level  int
+	length int
+	cmp    func(a, b T) int
+}
+
+func newSkipList[T any](cmp func(a, b T) int) *skipList[T] {
+	return &skipList[T]{
+		head:  &skipNode[T]{levels: make([]skipLevel[T], skipListMaxLevel)},
+		level: 1,
+		cmp:   cmp,
+	}
+}
+
+func (l *skipList[T]) randomLevel() int {
+	lv := 1
+	for r := rand.Int63(); lv < skipListMaxLevel && r%skipListP == 0; r /= skipListP {
+		lv++
+	}
+	return lv
+}
+
+// first returns the node of the min value
+func (l *skipList[T]) first() *skipNode[T] {
+	return l.head.levels[0].next
+}
+
+// find returns the node whose value equals v
+func (l *skipList[T]) find(v T) *skipNode[T] {
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && l.cmp(x.levels[i].next.val, v) < 0 {
+			x = x.levels[i].next
+		}
+	}
+	x = x.levels[0].next
+	if x != nil && l.cmp(x.val, v) == 0 {
+		return x
+	}
+	return nil
+}
+
+// bound returns the last node before v and the first node after v.
+// Node equal to v is regarded as after v, or before v if orEqual is true.
+// The returned nodes are nil if not exist
+func (l *skipList[T]) bound(v T, orEqual bool) (before, after *skipNode[T]) {
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil {
+			c := l.cmp(x.levels[i].next.val, v)
+			if c > 0 || c == 0 && !orEqual {
+				break
+			}
+			x = x.levels[i].next
+		}
+	}
+	after = x.levels[0].next
+	if x == l.head {
+		return nil, after
+	}
+	return x, after
+}
+
+// rank returns the 0-based rank of v, or -1 if v doesn't exist
+func (l *skipList[T]) rank(v T) int {
+	var rank int
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && l.cmp(x.levels[i].next.val, v) <= 0 {
+			rank += x.levels[i].span
+			x = x.levels[i].next
+		}
+		if x != l.head && l.cmp(x.val, v) == 0 {
+			return rank - 1
+		}
+	}
+	return -1
+}
+
+// nodeAt returns the node at 0-based rank k, or nil if k is out of range
+func (l *skipList[T]) nodeAt(k int) *skipNode[T] {
+	if k < 0 || k >= l.length {
+		return nil
+	}
+	var traversed int
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && traversed+x.levels[i].span <= k+1 {
+			traversed += x.levels[i].span
+			x = x.levels[i].next
+		}
+		if traversed == k+1 {
+			return x
+		}
+	}
+	return nil
+}
+
+// insert adds v and returns whether it's added, v isn't added if it exists already
+func (l *skipList[T]) insert(v T) bool {
+	var update [skipListMaxLevel]*skipNode[T]
+	var rank [skipListMaxLevel]int
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.levels[i].next != nil && l.cmp(x.levels[i].next.val, v) < 0 {
+			rank[i] += x.levels[i].span
+			x = x.levels[i].next
+		}
+		update[i] = x
+	}
+	if n := x.levels[0].next; n != nil && l.cmp(n.val, v) == 0 {
+		return false
+	}
+
+	lv := l.randomLevel()
+	if lv > l.level {
+		for i := l.level; i < lv; i++ {
+			update[i] = l.head
+			l.head.levels[i].span = l.length
+		}
+		l.level = lv
+	}
+
+	x = &skipNode[T]{val: v, levels: make([]skipLevel[T], lv)}
+	for i := 0; i < lv; i++ {
+		x.levels[i].next = update[i].levels[i].next
+		update[i].levels[i].next = x
+		x.levels[i].span = update[i].levels[i].span - (rank[0] - rank[i])
+		update[i].levels[i].span = rank[0] - rank[i] + 1
+	}
+	for i := lv; i < l.level; i++ {
+		update[i].levels[i].span++
+	}
+
+	if update[0] != l.head {
+		x.pre = update[0]
+	}
+	if x.levels[0].next != nil {
+		x.levels[0].next.pre = x
+	} else {
+		l.tail = x
+	}
+	l.length++
+	return true
+}
+
+// remove deletes v and returns whether it existed
+func (l *skipList[T]) remove(v T) bool {
+	var update [skipListMaxLevel]*skipNode[T]
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && l.cmp(x.levels[i].next.val, v) < 0 {
+			x = x.levels[i].next
+		}
+		update[i] = x
+	}
+	x = x.levels[0].next
+	if x == nil || l.cmp(x.val, v) != 0 {
+		return false
+	}
+	l.removeNode(x, update[:])
+	return true
+}
+
+// removeNode unlinks x, update holds the last node before x on each level
+func (l *skipList[T]) removeNode(x *skipNode[T], update []*skipNode[T]) {
+	for i := 0; i < l.level; i++ {
+		if update[i].levels[i].next == x {
+			update[i].levels[i].span += x.levels[i].span - 1
+			update[i].levels[i].next = x.levels[i].next
+		} else {
+			update[i].levels[i].span--
+		}
+	}
+	if x.levels[0].next != nil {
+		x.levels[0].next.pre = x.pre
+	} else {
+		l.tail = x.pre
+	}
+	for l.level > 1 && l.head.levels[l.level-1].next == nil {
+		l.level--
+	}
+	l.length--
+}
+
+// removeRange deletes nodes whose values are in [lo, hi] and returns the number of deleted nodes
+func (l *skipList[T]) removeRange(lo, hi T) int {
+	var update [skipListMaxLevel]*skipNode[T]
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && l.cmp(x.levels[i].next.val, lo) < 0 {
+			x = x.levels[i].next
+		}
+		update[i] = x
+	}
+
+	var n int
+	x = x.levels[0].next
+	for x != nil && l.cmp(x.val, hi) <= 0 {
+		next := x.levels[0].next
+		l.removeNode(x, update[:])
+		x = next
+		n++
+	}
+	return n
+}
+
+// clear removes all nodes, cmp is kept unchanged so that it can be read without lock
+func (l *skipList[T]) clear() {
+	l.head = &skipNode[T]{levels: make([]skipLevel[T], skipListMaxLevel)}
+	l.tail = nil
+	l.level = 1
+	l.length = 0
+}
+
+// copy returns a copy of itself in linear time
+func (l *skipList[T]) copy() *skipList[T] {
+	b := newSkipListBuilder[T](l.cmp)
+	for x := l.first(); x != nil; x = x.levels[0].next {
+		b.push(x.val)
+	}
+	return b.build()
+}
+
+// toList returns values in asc order
+func (l *skipList[T]) toList() []T {
+	r := make([]T, 0, l.length)
+	for x := l.first(); x != nil; x = x.levels[0].next {
+		r = append(r, x.val)
+	}
+	return r
+}
+
+// skipListBuilder builds a skipList from values in asc order in linear time
+type skipListBuilder[T any] struct {
+	l    *skipList[T]
+	last [skipListMaxLevel]*skipNode[T]
+	rank [skipListMaxLevel]int
+}
+
+func newSkipListBuilder[T any](cmp func(a, b T) int) *skipListBuilder[T] {
+	b := &skipListBuilder[T]{l: newSkipList[T](cmp)}
+	for i := range b.last {
+		b.last[i] = b.l.head
+	}
+	return b
+}
+
+// push appends v, which must be greater than all values pushed before
+func (b *skipListBuilder[T]) push(v T) {
+	l := b.l
+	lv := l.randomLevel()
+	if lv > l.level {
+		l.level = lv
+	}
+
+	x := &skipNode[T]{val: v, pre: l.tail, levels: make([]skipLevel[T], lv)}
+	l.length++
+	for i := 0; i < lv; i++ {
+		b.last[i].levels[i].next = x
+		b.last[i].levels[i].span = l.length - b.rank[i]
+		b.last[i] = x
+		b.rank[i] = l.length
+	}
+	l.tail = x
+}
+
+// build returns the built skipList, the builder mustn't be used anymore
+func (b *skipListBuilder[T]) build() *skipList[T] {
+	l := b.l
+	for i := 0; i < l.level; i++ {
+		b.last[i].levels[i].span = l.length - b.rank[i]
+	}
+	return l
+}
+
+// mergeSkipList merges l and sorted vals in linear time and returns a new skipList,
+// which holds values only in l if keepL, values in both if keepBoth and values only in vals if keepVals.
+// vals must be in strictly asc order of l.cmp
+func mergeSkipList[T any](l *skipList[T], vals []T, keepL, keepBoth, keepVals bool) *skipList[T] {
+	b := newSkipListBuilder[T](l.cmp)
+	x := l.first()
+	var i int
+	for x != nil && i < len(vals) {
+		c := l.cmp(x.val, vals[i])
+		switch {
+		case c < 0:
+			if keepL {
+				b.push(x.val)
+			}
+			x = x.levels[0].next
+		case c > 0:
+			if keepVals {
+				b.push(vals[i])
+			}
+			i++
+		default:
+			if keepBoth {
+				b.push(x.val)
+			}
+			x = x.levels[0].next
+			i++
+		}
+	}
+	for ; keepL && x != nil; x = x.levels[0].next {
+		b.push(x.val)
+	}
+	for ; keepVals && i < len(vals); i++ {
+		b.push(vals[i])
+	}
+	return b.build()
+}
+
+// isSortedBy returns whether vals are in strictly asc order of cmp
+func isSortedBy[T any](vals []T, cmp func(a, b T) int) bool {
+	for i := 1; i < len(vals); i++ {
+		if cmp(vals[i-1], vals[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
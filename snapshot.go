@@ -0,0 +1,85 @@
+package goset
+
+// SetSnapshot is a read-only point-in-time view of a Set, it's created by Set.Snapshot.
+// It needs no lock, so reading it never blocks the Set's writers
+type SetSnapshot[T comparable] struct {
+	u UnsafeSet[T]
+}
+
+// Snapshot returns a read-only view of the current elements in O(1).
+// Elements are copied only when the Set is modified for the first time after the snapshot is taken (copy-on-write),
+// so later modifications of the Set don't affect the snapshot
+//
+// for example:
+// var s = NewSet(1, 2)
+// var v = s.Snapshot()
+// s.Add(3)
+// v.Has(3) returns false
+func (s *Set[T]) Snapshot() *SetSnapshot[T] {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.shared = true
+	return &SetSnapshot[T]{u: s.u}
+}
+
+// Length returns SetSnapshot length
+func (s *SetSnapshot[T]) Length() int {
+	return s.u.Length()
+}
+
+// Has returns whether v exists in SetSnapshot
+func (s *SetSnapshot[T]) Has(v T) bool {
+	return s.u.Has(v)
+}
+
+// ToList returns data slice
+func (s *SetSnapshot[T]) ToList() []T {
+	return s.u.ToList()
+}
+
+// Equals returns whether SetSnapshot s has the same members with set t
+func (s *SetSnapshot[T]) Equals(t ReadableSet[T]) bool {
+	return s.u.Equals(t)
+}
+
+// IsSub returns whether it's a part of set t
+func (s *SetSnapshot[T]) IsSub(t ReadableSet[T]) bool {
+	return s.u.IsSub(t)
+}
+
+// ToMutable returns a new Set with the same elements
+func (s *SetSnapshot[T]) ToMutable() *Set[T] {
+	return &Set[T]{u: *s.u.Copy()}
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *SetSnapshot[T]) Relation(t ReadableSet[T]) SetRelation {
+	return relation[T](s, t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *SetSnapshot[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *SetSnapshot[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *SetSnapshot[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *SetSnapshot[T]) IsDisjoint(t ReadableSet[T]) bool {
+	return disjoint[T](s, t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *SetSnapshot[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
+}
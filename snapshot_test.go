@@ -0,0 +1,67 @@
+package goset
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSetSnapshot(t *testing.T) {
+	s := NewSet(1, 2, 3)
+	v := s.Snapshot()
+	if !v.Equals(NewSet(1, 2, 3)) || !v.IsSub(s) || v.Length() != 3 {
+		t.Fatalf("s.Snapshot() got unexpected %v", v.ToList())
+	}
+
+	s.Add(4)
+	s.Delete(1)
+	if !v.Equals(NewSet(1, 2, 3)) || v.Has(4) || !v.Has(1) {
+		t.Fatalf("snapshot after s.Add(4) and s.Delete(1) got unexpected %v", v.ToList())
+	}
+	if !s.Equals(NewSet(2, 3, 4)) {
+		t.Fatalf("s.Add(4) and s.Delete(1) got unexpected %v", s.ToList())
+	}
+
+	w := s.Snapshot()
+	s.Clear()
+	s.Add(5)
+	if !w.Equals(NewSet(2, 3, 4)) || !s.Equals(NewSet(5)) {
+		t.Fatalf("snapshot after s.Clear() got unexpected %v, s got %v", w.ToList(), s.ToList())
+	}
+	if r := s.Union(w); !r.Equals(NewSet(2, 3, 4, 5)) {
+		t.Fatalf("s.Union(snapshot) got unexpected %v", r.ToList())
+	}
+
+	m := w.ToMutable()
+	m.Add(6)
+	if w.Has(6) {
+		t.Fatalf("w.ToMutable() shares data with w")
+	}
+}
+
+func TestSetSnapshotConcurrent(t *testing.T) {
+	s := NewSet[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 100; i < 2000; i++ {
+			s.Add(i)
+			s.Delete(i - 100)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			v := s.Snapshot()
+			if n := len(v.ToList()); n != 100 && n != 101 {
+				t.Errorf("snapshot got unexpected length %d", n)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,203 @@
+package goset
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	errNilSQLSet      = errors.New("goset: SQLSet.Set is nil")
+	errEmptyDelimited = errors.New("goset: SQLSet can't store a set of only an empty string with Delimiter")
+)
+
+// SQLSet adapts a set to database/sql, so that it can be used as a query argument or a scan destination.
+// The set is stored as a JSON array text, or as a string whose elements are joined by Delimiter if it's not empty.
+// For delimited strings, string elements are stored as they are, and other elements are stored as JSON texts.
+// Value returns an error if a stored element contains the delimiter,
+// or if the set only has an empty string, which can't be told from an empty set
+//
+// for example:
+// var tags = NewStrSet()
+// db.QueryRow("SELECT tags FROM posts WHERE id=?", 1).Scan(SQLSet[string]{Set: tags, Delimiter: ","})
+type SQLSet[T comparable] struct {
+	Set       Interface[T]
+	Delimiter string
+}
+
+// Value implements driver.Valuer
+func (s SQLSet[T]) Value() (driver.Value, error) {
+	if s.Set == nil {
+		return nil, errNilSQLSet
+	}
+	vals := s.Set.ToList()
+	if s.Delimiter == "" {
+		data, err := marshalJSON(vals)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+
+	elems := make([]string, len(vals))
+	for i, v := range vals {
+		e, err := formatElem(v)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(e, s.Delimiter) {
+			return nil, fmt.Errorf("goset: SQLSet element %q contains the delimiter %q", e, s.Delimiter)
+		}
+		elems[i] = e
+	}
+	if len(elems) == 1 && elems[0] == "" {
+		return nil, errEmptyDelimited
+	}
+	return strings.Join(elems, s.Delimiter), nil
+}
+
+// Scan implements sql.Scanner, elements of the set are replaced by scanned ones, NULL is scanned as an empty set
+func (s SQLSet[T]) Scan(src any) error {
+	if s.Set == nil {
+		return errNilSQLSet
+	}
+
+	var text string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("goset: can't scan %T into SQLSet", src)
+	}
+
+	var vals []T
+	if s.Delimiter == "" {
+		if text != "" {
+			if err := json.Unmarshal([]byte(text), &vals); err != nil {
+				return err
+			}
+		}
+	} else if text != "" {
+		elems := strings.Split(text, s.Delimiter)
+		vals = make([]T, len(elems))
+		for i, e := range elems {
+			v, err := parseElem[T](e)
+			if err != nil {
+				return err
+			}
+			vals[i] = v
+		}
+	}
+
+	if l, ok := s.Set.(loader[T]); ok {
+		return l.load(vals)
+	}
+	s.Set.Clear()
+	s.Set.Add(vals...)
+	return nil
+}
+
+// formatElem formats v as it is if it's a string, or as a JSON text
+func formatElem[T comparable](v T) (string, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// parseElem is the reverse of formatElem
+func parseElem[T comparable](s string) (v T, err error) {
+	if rv := reflect.ValueOf(&v).Elem(); rv.Kind() == reflect.String {
+		rv.SetString(s)
+		return v, nil
+	}
+	err = json.Unmarshal([]byte(s), &v)
+	return v, err
+}
+
+// Value implements driver.Valuer, Set is stored as a JSON array text
+func (s *Set[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, Set is scanned from a JSON array text
+func (s *Set[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
+
+// Value implements driver.Valuer, FifoSet is stored as a JSON array text
+func (s *FifoSet[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, FifoSet is scanned from a JSON array text
+func (s *FifoSet[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
+
+// Value implements driver.Valuer, FiloSet is stored as a JSON array text
+func (s *FiloSet[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, FiloSet is scanned from a JSON array text
+func (s *FiloSet[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
+
+// Value implements driver.Valuer, SortedSet is stored as a JSON array text
+func (s *SortedSet[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, SortedSet is scanned from a JSON array text
+func (s *SortedSet[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
+
+// Value implements driver.Valuer, ShardedSet is stored as a JSON array text
+func (s *ShardedSet[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, ShardedSet is scanned from a JSON array text
+func (s *ShardedSet[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
+
+// Value implements driver.Valuer, UnsafeSet is stored as a JSON array text
+func (s *UnsafeSet[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, UnsafeSet is scanned from a JSON array text
+func (s *UnsafeSet[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
+
+// Value implements driver.Valuer, UnsafeSortedSet is stored as a JSON array text
+func (s *UnsafeSortedSet[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, UnsafeSortedSet is scanned from a JSON array text
+func (s *UnsafeSortedSet[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
+
+// Value implements driver.Valuer, SyncSet is stored as a JSON array text
+func (s *SyncSet[T]) Value() (driver.Value, error) {
+	return SQLSet[T]{Set: s}.Value()
+}
+
+// Scan implements sql.Scanner, SyncSet is scanned from a JSON array text
+func (s *SyncSet[T]) Scan(src any) error {
+	return SQLSet[T]{Set: s}.Scan(src)
+}
@@ -0,0 +1,209 @@
+package goset
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDriver stores values in memory, it supports only 2 kinds of statements:
+// "SET key" with 1 argument stores the argument by key, and "GET key" queries the value of key
+type fakeDriver struct {
+	m      sync.Mutex
+	values map[string]driver.Value
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	op    string
+	key   string
+	input int
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	op, key, _ := strings.Cut(query, " ")
+	st := &fakeStmt{d: c.d, op: op, key: key}
+	if op == "SET" {
+		st.input = 1
+	}
+	return st, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (st *fakeStmt) Close() error {
+	return nil
+}
+
+func (st *fakeStmt) NumInput() int {
+	return st.input
+}
+
+func (st *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	st.d.m.Lock()
+	defer st.d.m.Unlock()
+
+	st.d.values[st.key] = args[0]
+	return driver.RowsAffected(1), nil
+}
+
+func (st *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	st.d.m.Lock()
+	defer st.d.m.Unlock()
+
+	return &fakeRows{value: st.d.values[st.key]}, nil
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+var fake = &fakeDriver{values: make(map[string]driver.Value)}
+
+func init() {
+	sql.Register("goset-fake", fake)
+}
+
+func TestSQL(t *testing.T) {
+	db, err := sql.Open("goset-fake", "")
+	if err != nil {
+		t.Fatalf("sql.Open() got unexpected error %v", err)
+	}
+	defer db.Close()
+
+	set := func(key string, v any) {
+		t.Helper()
+		if _, err := db.Exec("SET "+key, v); err != nil {
+			t.Fatalf("db.Exec(SET %s) got unexpected error %v", key, err)
+		}
+	}
+	get := func(key string, dest any) {
+		t.Helper()
+		if err := db.QueryRow("GET " + key).Scan(dest); err != nil {
+			t.Fatalf("db.QueryRow(GET %s) got unexpected error %v", key, err)
+		}
+	}
+
+	set("tags", NewStrSet("go", "sql"))
+	if v := fake.values["tags"]; v != `["go","sql"]` && v != `["sql","go"]` {
+		t.Fatalf("StrSet is stored as unexpected %v", v)
+	}
+	tags := NewStrSet("stale")
+	get("tags", tags)
+	if !tags.Equals(NewStrSet("go", "sql")) {
+		t.Fatalf("StrSet is scanned as unexpected %v", tags.ToList())
+	}
+
+	set("ids", SQLSet[int]{Set: NewSortedSet(3, 1, 2), Delimiter: ","})
+	if v := fake.values["ids"]; v != "1,2,3" {
+		t.Fatalf("SQLSet is stored as unexpected %v", v)
+	}
+	ids := NewIntSet()
+	get("ids", SQLSet[int]{Set: ids, Delimiter: ","})
+	if !ids.Equals(NewIntSet(1, 2, 3)) {
+		t.Fatalf("SQLSet is scanned as unexpected %v", ids.ToList())
+	}
+	sorted := NewSortedSet[int]()
+	get("ids", SQLSet[int]{Set: sorted, Delimiter: ","})
+	if r := sorted.ToList(); !reflect.DeepEqual(r, []int{1, 2, 3}) {
+		t.Fatalf("SortedSet is scanned as unexpected %v", r)
+	}
+
+	set("stack", SQLSet[string]{Set: NewFiloSet("a", "b", "c"), Delimiter: "|"})
+	stack := NewFiloSet[string]()
+	get("stack", SQLSet[string]{Set: stack, Delimiter: "|"})
+	if r := stack.ToList(); !reflect.DeepEqual(r, []string{"c", "b", "a"}) {
+		t.Fatalf("FiloSet is scanned as unexpected %v", r)
+	}
+
+	set("words", SQLSet[string]{Set: NewStrSet("a b", ""), Delimiter: ","})
+	words := NewStrSet()
+	get("words", SQLSet[string]{Set: words, Delimiter: ","})
+	if !words.Equals(NewStrSet("a b", "")) {
+		t.Fatalf("SQLSet with an empty string is scanned as unexpected %v", words.ToList())
+	}
+
+	type point struct {
+		X, Y int
+	}
+	set("points", SQLSet[point]{Set: NewSet(point{1, 2}), Delimiter: "|"})
+	points := NewSet[point]()
+	get("points", SQLSet[point]{Set: points, Delimiter: "|"})
+	if !points.Equals(NewSet(point{1, 2})) {
+		t.Fatalf("SQLSet of structs is scanned as unexpected %v", points.ToList())
+	}
+
+	for _, v := range []driver.Valuer{
+		SQLSet[string]{Set: NewStrSet("a,b"), Delimiter: ","},
+		SQLSet[string]{Set: NewStrSet(""), Delimiter: ","},
+		SQLSet[point]{Set: NewSet(point{1, 2}), Delimiter: ","},
+	} {
+		if r, err := v.Value(); err == nil {
+			t.Fatalf("%v can't be stored, but got unexpected %v", v, r)
+		}
+	}
+
+	set("shards", NewShardedSet(1, 2))
+	shards := NewShardedSet(3)
+	get("shards", shards)
+	if !shards.Equals(NewSet(1, 2)) {
+		t.Fatalf("ShardedSet is scanned as unexpected %v", shards.ToList())
+	}
+
+	set("sync", Synchronized[int](NewUnsafeSortedSet(2, 1)))
+	if v := fake.values["sync"]; v != "[1,2]" {
+		t.Fatalf("SyncSet is stored as unexpected %v", v)
+	}
+	unsafe := NewUnsafeSet(3)
+	get("sync", unsafe)
+	if !unsafe.Equals(NewSet(1, 2)) {
+		t.Fatalf("UnsafeSet is scanned as unexpected %v", unsafe.ToList())
+	}
+
+	set("null", nil)
+	get("null", tags)
+	if tags.Length() != 0 {
+		t.Fatalf("NULL is scanned as unexpected %v", tags.ToList())
+	}
+
+	set("bad", "[1,")
+	if err = db.QueryRow("GET bad").Scan(ids); err == nil {
+		t.Fatalf("scanning bad JSON got unexpected nil error")
+	}
+}
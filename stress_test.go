@@ -0,0 +1,140 @@
+package goset
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+// algebraSet is implemented by sets supporting set algebra
+type algebraSet[S any] interface {
+	Interface[int]
+	Copy() S
+	Union(t ReadableSet[int]) S
+	Intersect(t ReadableSet[int]) S
+	Subtract(t ReadableSet[int]) S
+	Complement(t ReadableSet[int]) S
+	UnionWith(t ReadableSet[int]) int
+	IntersectWith(t ReadableSet[int]) int
+	SubtractWith(t ReadableSet[int]) int
+	SymmetricDifferenceWith(t ReadableSet[int]) int
+	Relation(t ReadableSet[int]) SetRelation
+	IsDisjoint(t ReadableSet[int]) bool
+}
+
+// stress runs all operations on a and b concurrently, with others as the operands of binary operations,
+// and fails if they don't finish in time, which means a deadlock
+func stress[S algebraSet[S]](t *testing.T, a, b S, others ...ReadableSet[int]) {
+	t.Helper()
+	const (
+		goroutines = 8
+		rounds     = 300
+	)
+	operands := append([]ReadableSet[int]{a, b}, others...)
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < rounds; i++ {
+				s := a
+				if r.Intn(2) == 0 {
+					s = b
+				}
+				o := operands[r.Intn(len(operands))]
+				v := r.Intn(50)
+				switch r.Intn(19) {
+				case 0:
+					s.Add(v, v+1)
+				case 1:
+					s.Delete(v)
+				case 2:
+					s.Has(v)
+				case 3:
+					s.Length()
+				case 4:
+					s.ToList()
+				case 5:
+					s.Copy()
+				case 6:
+					s.Equals(o)
+				case 7:
+					s.IsSub(o)
+				case 8:
+					s.Union(o)
+				case 9:
+					s.Intersect(o)
+				case 10:
+					s.Subtract(o)
+				case 11:
+					s.Complement(o)
+				case 12:
+					if r.Intn(20) == 0 {
+						s.Clear()
+					}
+				case 13:
+					s.UnionWith(o)
+				case 14:
+					s.IntersectWith(o)
+				case 15:
+					s.SubtractWith(o)
+				case 16:
+					s.SymmetricDifferenceWith(o)
+				case 17:
+					s.Relation(o)
+				case 18:
+					s.IsDisjoint(o)
+				}
+			}
+		}(int64(g))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatalf("operations didn't finish in time, they may be deadlocked")
+	}
+}
+
+func TestStress(t *testing.T) {
+	vals := []int{1, 2, 3, 4, 5}
+	set := NewSet(vals...)
+	fifo := NewFifoSet(vals...)
+	filo := NewFiloSet(vals...)
+	sorted := NewSortedSet(vals...)
+	sharded := NewShardedSet(vals...)
+	syncSet := Synchronized[int](NewUnsafeSet(vals...))
+
+	t.Run("Set", func(t *testing.T) {
+		t.Parallel()
+		stress(t, set, NewSet(vals...), fifo, sorted, syncSet)
+	})
+	t.Run("FifoSet", func(t *testing.T) {
+		t.Parallel()
+		stress(t, fifo, NewFifoSet(vals...), set, filo, syncSet)
+	})
+	t.Run("FiloSet", func(t *testing.T) {
+		t.Parallel()
+		stress(t, filo, NewFiloSet(vals...), fifo, sharded, syncSet)
+	})
+	t.Run("SortedSet", func(t *testing.T) {
+		t.Parallel()
+		stress(t, sorted, NewSortedSet(vals...), set, sharded, syncSet)
+	})
+	t.Run("ShardedSet", func(t *testing.T) {
+		t.Parallel()
+		stress(t, sharded, NewShardedSet(vals...), sorted, filo, syncSet)
+	})
+	t.Run("SyncSet", func(t *testing.T) {
+		t.Parallel()
+		stress(t, syncSet, Synchronized[int](NewUnsafeSet(vals...)), set, sorted, filo)
+	})
+}
@@ -0,0 +1,230 @@
+package goset
+
+import "sync"
+
+// SyncSet is a goroutine safe wrapper of a set, it's created by Synchronized
+type SyncSet[T comparable] struct {
+	m sync.RWMutex
+	s Interface[T]
+}
+
+// Synchronized wraps s into a goroutine safe set, which guards all accesses to s by a lock.
+// s mustn't be accessed directly anymore after being wrapped
+//
+// for example:
+// var s = Synchronized[int](NewUnsafeSet[int]())
+func Synchronized[T comparable](s Interface[T]) *SyncSet[T] {
+	return &SyncSet[T]{s: s}
+}
+
+// Add adds elements
+func (s *SyncSet[T]) Add(vals ...T) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.s.Add(vals...)
+}
+
+// Delete deletes elements
+func (s *SyncSet[T]) Delete(vals ...T) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.s.Delete(vals...)
+}
+
+// Clear clears all elements
+func (s *SyncSet[T]) Clear() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.s.Clear()
+}
+
+// Length returns SyncSet length
+func (s *SyncSet[T]) Length() int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return s.s.Length()
+}
+
+// Has returns whether v exists in SyncSet
+func (s *SyncSet[T]) Has(v T) bool {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return s.s.Has(v)
+}
+
+// ToList returns data slice
+func (s *SyncSet[T]) ToList() []T {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return s.s.ToList()
+}
+
+// Equals returns whether SyncSet s has the same members with set t
+func (s *SyncSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return s.s.Equals(t)
+}
+
+// IsSub returns whether it's a part of set t
+func (s *SyncSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return s.s.IsSub(t)
+}
+
+// copy returns a deep copy of the wrapped set, sets other than UnsafeSet and UnsafeSortedSet are copied into an UnsafeSet.
+// It must be called with the lock held
+func (s *SyncSet[T]) copy() Interface[T] {
+	switch o := s.s.(type) {
+	case *UnsafeSet[T]:
+		return o.Copy()
+	case *UnsafeSortedSet[T]:
+		return o.Copy()
+	}
+	return NewUnsafeSet(s.s.ToList()...)
+}
+
+// Copy returns a new SyncSet wrapping a deep copy of the wrapped set
+func (s *SyncSet[T]) Copy() *SyncSet[T] {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return &SyncSet[T]{s: s.copy()}
+}
+
+// Union unions with set t and returns a new SyncSet
+func (s *SyncSet[T]) Union(t ReadableSet[T]) *SyncSet[T] {
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	r := s.copy()
+	unionWith(r, t)
+	return &SyncSet[T]{s: r}
+}
+
+// Intersect returns a new SyncSet whose elements exist in both sets
+func (s *SyncSet[T]) Intersect(t ReadableSet[T]) *SyncSet[T] {
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	r := s.copy()
+	intersectWith(r, t)
+	return &SyncSet[T]{s: r}
+}
+
+// Subtract returns a new SyncSet whose elements exist in itself but don't exist in set t
+func (s *SyncSet[T]) Subtract(t ReadableSet[T]) *SyncSet[T] {
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	r := s.copy()
+	subtractWith(r, t)
+	return &SyncSet[T]{s: r}
+}
+
+// Complement returns a new SyncSet whose elements only exist in one set
+func (s *SyncSet[T]) Complement(t ReadableSet[T]) *SyncSet[T] {
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	r := s.copy()
+	symmetricDifferenceWith(r, t)
+	return &SyncSet[T]{s: r}
+}
+
+// UnionWith adds elements of set t, and returns the number of added elements
+func (s *SyncSet[T]) UnionWith(t ReadableSet[T]) int {
+	t = lockFree(t)
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return unionWith(s.s, t)
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *SyncSet[T]) IntersectWith(t ReadableSet[T]) int {
+	t = lockFree(t)
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return intersectWith(s.s, t)
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *SyncSet[T]) SubtractWith(t ReadableSet[T]) int {
+	t = lockFree(t)
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return subtractWith(s.s, t)
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *SyncSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	t = lockFree(t)
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return symmetricDifferenceWith(s.s, t)
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *SyncSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return relation[T](s.s, t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *SyncSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *SyncSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *SyncSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *SyncSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	t = lockFree(t)
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return disjoint[T](s.s, t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *SyncSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
+}
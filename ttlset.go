@@ -0,0 +1,417 @@
+package goset
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Clock tells the current time to TTLSet, it can be replaced by a fake clock in tests
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// TTLSet is a goroutine safe set whose elements expire after their ttl.
+// Expired elements are ignored by all methods, and removed lazily when the set is modified or listed,
+// or by the janitor started by StartJanitor
+type TTLSet[T comparable] struct {
+	m sync.RWMutex
+	// data maps elements to the time they expire at
+	data     map[T]time.Time
+	expiries expiryHeap[T]
+	ttl      time.Duration
+	clock    Clock
+	onExpire func(v T)
+	// stop stops the janitor, it's nil if the janitor isn't running
+	stop chan struct{}
+}
+
+// NewTTLSet creates a new TTLSet whose elements expire after defaultTTL by default.
+// It panics if defaultTTL isn't positive
+//
+// for example:
+// var s=NewTTLSet[string](10*time.Minute)
+// s.Add("a")
+// s.Has("a") returns false after 10 minutes
+func NewTTLSet[T comparable](defaultTTL time.Duration, vals ...T) *TTLSet[T] {
+	return NewTTLSetWithClock(defaultTTL, systemClock{}, vals...)
+}
+
+// NewTTLSetWithClock creates a new TTLSet like NewTTLSet, which tells the time by clock
+func NewTTLSetWithClock[T comparable](defaultTTL time.Duration, clock Clock, vals ...T) *TTLSet[T] {
+	if defaultTTL <= 0 {
+		panic(fmt.Sprintf("goset: ttl %v isn't positive", defaultTTL))
+	}
+	s := &TTLSet[T]{data: make(map[T]time.Time), ttl: defaultTTL, clock: clock}
+	s.Add(vals...)
+	return s
+}
+
+// update runs fn with the write lock held after removing expired elements.
+// The expiry callback is called after unlocking, so that it can access the set
+func (s *TTLSet[T]) update(fn func(now time.Time)) {
+	s.m.Lock()
+	now := s.clock.Now()
+	var expired []T
+	for s.expiries.Len() > 0 && !now.Before(s.expiries.entries[0].at) {
+		e := heap.Pop(&s.expiries).(expiry[T])
+		// entries of deleted or refreshed elements are stale
+		if at, ok := s.data[e.val]; ok && at.Equal(e.at) {
+			delete(s.data, e.val)
+			if s.onExpire != nil {
+				expired = append(expired, e.val)
+			}
+		}
+	}
+	if fn != nil {
+		fn(now)
+	}
+	onExpire := s.onExpire
+	s.m.Unlock()
+
+	for _, v := range expired {
+		onExpire(v)
+	}
+}
+
+// add adds v which expires at at, or refreshes its expiry time if it exists.
+// It must be called with the write lock held
+func (s *TTLSet[T]) add(v T, at time.Time) {
+	s.data[v] = at
+	heap.Push(&s.expiries, expiry[T]{val: v, at: at})
+	if s.expiries.Len() > 2*len(s.data)+16 {
+		// drop stale entries
+		s.expiries.entries = s.expiries.entries[:0]
+		for v, at := range s.data {
+			s.expiries.entries = append(s.expiries.entries, expiry[T]{val: v, at: at})
+		}
+		heap.Init(&s.expiries)
+	}
+}
+
+// live returns the elements not expired
+func (s *TTLSet[T]) live() *UnsafeSet[T] {
+	var u *UnsafeSet[T]
+	s.update(func(time.Time) {
+		u = &UnsafeSet[T]{data: make(map[T]struct{}, len(s.data))}
+		for v := range s.data {
+			u.data[v] = struct{}{}
+		}
+	})
+	return u
+}
+
+// Add adds elements which expire after the default ttl, the expiry time of existing elements is refreshed
+func (s *TTLSet[T]) Add(vals ...T) {
+	if len(vals) == 0 {
+		return
+	}
+	s.update(func(now time.Time) {
+		for _, v := range vals {
+			s.add(v, now.Add(s.ttl))
+		}
+	})
+}
+
+// AddWithTTL adds v which expires after d, the expiry time is refreshed if v exists.
+// It panics if d isn't positive
+func (s *TTLSet[T]) AddWithTTL(v T, d time.Duration) {
+	if d <= 0 {
+		panic(fmt.Sprintf("goset: ttl %v isn't positive", d))
+	}
+	s.update(func(now time.Time) {
+		s.add(v, now.Add(d))
+	})
+}
+
+// Delete deletes elements, the expiry callback isn't called for them
+func (s *TTLSet[T]) Delete(vals ...T) {
+	s.update(func(time.Time) {
+		for _, v := range vals {
+			delete(s.data, v)
+		}
+	})
+}
+
+// Clear clears all elements, the expiry callback isn't called for them
+func (s *TTLSet[T]) Clear() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.data = make(map[T]time.Time)
+	s.expiries.entries = nil
+}
+
+// OnExpire sets the callback called with every expired element after the lock is released,
+// so the callback can access the TTLSet
+func (s *TTLSet[T]) OnExpire(fn func(v T)) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.onExpire = fn
+}
+
+// ExpiresAt returns the time v expires at, ok is false if v doesn't exist
+func (s *TTLSet[T]) ExpiresAt(v T) (at time.Time, ok bool) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	at, ok = s.data[v]
+	if !ok || !s.clock.Now().Before(at) {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// StartJanitor starts a goroutine removing expired elements every interval, so that the expiry callback is called in time.
+// It does nothing if the janitor is running. The janitor must be stopped by Close.
+// It panics if interval isn't positive
+func (s *TTLSet[T]) StartJanitor(interval time.Duration) {
+	if interval <= 0 {
+		panic(fmt.Sprintf("goset: janitor interval %v isn't positive", interval))
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	s.stop = stop
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.update(nil)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the janitor, the TTLSet can still be used after closing
+func (s *TTLSet[T]) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	return nil
+}
+
+// Length returns the number of elements not expired
+func (s *TTLSet[T]) Length() int {
+	var n int
+	s.update(func(time.Time) {
+		n = len(s.data)
+	})
+	return n
+}
+
+// Has returns whether v exists and isn't expired
+func (s *TTLSet[T]) Has(v T) bool {
+	_, ok := s.ExpiresAt(v)
+	return ok
+}
+
+// ToList returns data slice of elements not expired
+func (s *TTLSet[T]) ToList() []T {
+	return s.live().ToList()
+}
+
+// Copy returns a deep copy of itself with the same expiry times, the callback and the janitor aren't copied
+func (s *TTLSet[T]) Copy() *TTLSet[T] {
+	r := &TTLSet[T]{ttl: s.ttl, clock: s.clock}
+	s.update(func(time.Time) {
+		r.data = make(map[T]time.Time, len(s.data))
+		for v, at := range s.data {
+			r.data[v] = at
+		}
+		r.expiries.entries = append([]expiry[T](nil), s.expiries.entries...)
+	})
+	return r
+}
+
+// Equals returns whether TTLSet s has the same members with set t
+func (s *TTLSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	return s.live().Equals(lockFree(t))
+}
+
+// IsSub returns whether it's a part of set t
+func (s *TTLSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	return s.live().IsSub(lockFree(t))
+}
+
+// Union returns a new Set of elements in either set, expired elements are left out
+func (s *TTLSet[T]) Union(t ReadableSet[T]) *Set[T] {
+	return &Set[T]{u: *s.live().Union(lockFree(t))}
+}
+
+// Intersect returns a new Set whose elements exist in both sets, expired elements are left out
+func (s *TTLSet[T]) Intersect(t ReadableSet[T]) *Set[T] {
+	return &Set[T]{u: *s.live().Intersect(lockFree(t))}
+}
+
+// Subtract returns a new Set whose elements exist in itself but don't exist in set t, expired elements are left out
+func (s *TTLSet[T]) Subtract(t ReadableSet[T]) *Set[T] {
+	return &Set[T]{u: *s.live().Subtract(lockFree(t))}
+}
+
+// Complement returns a new Set whose elements only exist in one set, expired elements are left out
+func (s *TTLSet[T]) Complement(t ReadableSet[T]) *Set[T] {
+	return &Set[T]{u: *s.live().Complement(lockFree(t))}
+}
+
+// UnionWith adds elements of set t which expire after the default ttl, and returns the number of added elements.
+// The expiry time of existing elements isn't refreshed
+func (s *TTLSet[T]) UnionWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := lockFree(t).ToList()
+	var n int
+	s.update(func(now time.Time) {
+		for _, v := range vals {
+			if _, ok := s.data[v]; !ok {
+				s.add(v, now.Add(s.ttl))
+				n++
+			}
+		}
+	})
+	return n
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *TTLSet[T]) IntersectWith(t ReadableSet[T]) int {
+	t = lockFree(t)
+	var n int
+	s.update(func(time.Time) {
+		for v := range s.data {
+			if t == nil || !t.Has(v) {
+				delete(s.data, v)
+				n++
+			}
+		}
+	})
+	return n
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *TTLSet[T]) SubtractWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := lockFree(t).ToList()
+	var n int
+	s.update(func(time.Time) {
+		for _, v := range vals {
+			if _, ok := s.data[v]; ok {
+				delete(s.data, v)
+				n++
+			}
+		}
+	})
+	return n
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t which expire after the default ttl,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *TTLSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	vals := lockFree(t).ToList()
+	s.update(func(now time.Time) {
+		for _, v := range vals {
+			if _, ok := s.data[v]; ok {
+				delete(s.data, v)
+			} else {
+				s.add(v, now.Add(s.ttl))
+			}
+		}
+	})
+	return len(vals)
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *TTLSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	return s.live().Relation(lockFree(t))
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *TTLSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *TTLSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *TTLSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *TTLSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	return s.live().IsDisjoint(lockFree(t))
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *TTLSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
+}
+
+// expiry is an element with the time it expires at
+type expiry[T comparable] struct {
+	val T
+	at  time.Time
+}
+
+// expiryHeap is a min heap of expiries ordered by time. An entry is stale if its element is deleted or refreshed
+type expiryHeap[T comparable] struct {
+	entries []expiry[T]
+}
+
+func (h *expiryHeap[T]) Len() int {
+	return len(h.entries)
+}
+
+func (h *expiryHeap[T]) Less(i, j int) bool {
+	return h.entries[i].at.Before(h.entries[j].at)
+}
+
+func (h *expiryHeap[T]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+}
+
+func (h *expiryHeap[T]) Push(x any) {
+	h.entries = append(h.entries, x.(expiry[T]))
+}
+
+func (h *expiryHeap[T]) Pop() any {
+	n := len(h.entries)
+	x := h.entries[n-1]
+	h.entries = h.entries[:n-1]
+	return x
+}
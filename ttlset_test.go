@@ -0,0 +1,131 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock which only moves when it's advanced
+type fakeClock struct {
+	m   sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func TestTTLSet(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	s := NewTTLSetWithClock(10*time.Minute, clock, 1, 2)
+	s.AddWithTTL(3, time.Minute)
+	s.AddWithTTL(4, time.Hour)
+
+	var expired []int
+	s.OnExpire(func(v int) {
+		expired = append(expired, v)
+		s.Has(v)
+	})
+
+	clock.Advance(time.Minute)
+	if s.Has(3) || !s.Has(1) {
+		t.Fatalf("s.Has() after 1 minute got unexpected result")
+	}
+	if !s.Equals(NewSet(1, 2, 4)) || !reflect.DeepEqual(expired, []int{3}) {
+		t.Fatalf("s after 1 minute got unexpected %v, expired %v", s.ToList(), expired)
+	}
+
+	// adding again refreshes the expiry time
+	s.Add(1)
+	s.Delete(4)
+	clock.Advance(9 * time.Minute)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1}) || !reflect.DeepEqual(expired, []int{3, 2}) {
+		t.Fatalf("s.ToList() after 10 minutes got unexpected %v, expired %v", r, expired)
+	}
+	if at, ok := s.ExpiresAt(1); !ok || !at.Equal(time.Unix(0, 0).Add(11*time.Minute)) {
+		t.Fatalf("s.ExpiresAt(1) got unexpected %v %v", at, ok)
+	}
+
+	// operations ignore expired elements and work like Set
+	s.Add(5)
+	c := s.Copy()
+	clock.Advance(time.Minute)
+	if u := s.Union(NewSet(6)); !u.Equals(NewSet(5, 6)) {
+		t.Fatalf("s.Union() got unexpected %v", u.ToList())
+	}
+	if u := s.Intersect(NewSet(1, 5)); !u.Equals(NewSet(5)) {
+		t.Fatalf("s.Intersect() got unexpected %v", u.ToList())
+	}
+	if !s.Equals(NewSet(5)) || s.Length() != 1 || !c.Equals(s) || c.Has(1) {
+		t.Fatalf("s.Equals() got unexpected %v, copy %v", s.ToList(), c.ToList())
+	}
+
+	for i := 0; i < 100; i++ {
+		s.Add(5)
+	}
+	if s.expiries.Len() > 2*len(s.data)+16 {
+		t.Fatalf("stale expiries got unexpected %d", s.expiries.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("s.AddWithTTL(1, 0) should panic")
+		}
+	}()
+	s.AddWithTTL(1, 0)
+}
+
+func TestTTLSetJanitor(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	s := NewTTLSetWithClock(time.Second, clock, 1)
+	expired := make(chan int, 1)
+	s.OnExpire(func(v int) {
+		expired <- v
+	})
+	s.StartJanitor(time.Millisecond)
+	s.StartJanitor(time.Millisecond)
+	defer s.Close()
+
+	clock.Advance(time.Second)
+	select {
+	case v := <-expired:
+		if v != 1 {
+			t.Fatalf("janitor expired unexpected %v", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("janitor didn't expire elements")
+	}
+
+	if err := s.Close(); err != nil || s.stop != nil {
+		t.Fatalf("s.Close() got unexpected %v", err)
+	}
+	s.Add(2)
+	if !s.Has(2) {
+		t.Fatalf("s.Has(2) after closing got unexpected false")
+	}
+
+	// the panic happens in the caller's goroutine, and no janitor is started
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("s.StartJanitor(0) should panic")
+			}
+		}()
+		s.StartJanitor(0)
+	}()
+	if s.stop != nil {
+		t.Fatalf("s.StartJanitor(0) got unexpected running janitor")
+	}
+}
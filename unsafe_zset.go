@@ -0,0 +1,280 @@
+package goset
+
+import (
+	cmp "github.com/visforest/goset/v2/compare"
+)
+
+// NewUnsafeSortedSet creates a new UnsafeSortedSet whose elements are ordered by compare.Compare
+func NewUnsafeSortedSet[T cmp.Ordered](vals ...T) *UnsafeSortedSet[T] {
+	return NewUnsafeSortedSetFunc[T](cmp.Compare[T], vals...)
+}
+
+// NewUnsafeSortedSetFunc creates a new UnsafeSortedSet whose elements are ordered by cmp, see NewSortedSetFunc
+func NewUnsafeSortedSetFunc[T comparable](cmp func(a, b T) int, vals ...T) *UnsafeSortedSet[T] {
+	s := &UnsafeSortedSet[T]{list: newSkipList[T](cmp)}
+	s.Add(vals...)
+	return s
+}
+
+// UnsafeSortedSet has the same functions with SortedSet but without locks, so it's faster but not goroutine safe.
+// Use SortedSet or wrap it by Synchronized if it's shared among goroutines
+type UnsafeSortedSet[T comparable] struct {
+	list *skipList[T]
+}
+
+// Add adds elements
+func (s *UnsafeSortedSet[T]) Add(vals ...T) {
+	for _, v := range vals {
+		s.list.insert(v)
+	}
+}
+
+// Delete deletes elements
+func (s *UnsafeSortedSet[T]) Delete(vals ...T) {
+	for _, v := range vals {
+		s.list.remove(v)
+	}
+}
+
+// Clear clears all elements
+func (s *UnsafeSortedSet[T]) Clear() {
+	s.list.clear()
+}
+
+// Length returns UnsafeSortedSet length
+func (s *UnsafeSortedSet[T]) Length() int {
+	return s.list.length
+}
+
+// Has returns whether v exists in UnsafeSortedSet
+func (s *UnsafeSortedSet[T]) Has(v T) bool {
+	return s.list.find(v) != nil
+}
+
+// ToList returns data slice in asc order
+func (s *UnsafeSortedSet[T]) ToList() []T {
+	if s.list.length == 0 {
+		return nil
+	}
+	return s.list.toList()
+}
+
+// Copy returns a deep copy of itself
+func (s *UnsafeSortedSet[T]) Copy() *UnsafeSortedSet[T] {
+	return &UnsafeSortedSet[T]{list: s.list.copy()}
+}
+
+// empty returns a new empty UnsafeSortedSet in the same order
+func (s *UnsafeSortedSet[T]) empty() *UnsafeSortedSet[T] {
+	return &UnsafeSortedSet[T]{list: newSkipList[T](s.list.cmp)}
+}
+
+// Equals returns whether UnsafeSortedSet s has the same members with set t
+func (s *UnsafeSortedSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	return equals[T](s, t)
+}
+
+// EqualsOrdered returns whether UnsafeSortedSet s has the same elements with set t in the same order,
+// elements of t are in the order of its ToList
+func (s *UnsafeSortedSet[T]) EqualsOrdered(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*UnsafeSortedSet[T]); ok {
+		a, b := s.list.first(), o.list.first()
+		for ; a != nil && b != nil; a, b = a.levels[0].next, b.levels[0].next {
+			if a.val != b.val {
+				return false
+			}
+		}
+		return a == nil && b == nil
+	}
+	return s.equalsList(t.ToList())
+}
+
+// equalsList returns whether elements of UnsafeSortedSet s are vals in order
+func (s *UnsafeSortedSet[T]) equalsList(vals []T) bool {
+	if len(vals) != s.list.length {
+		return false
+	}
+	x := s.list.first()
+	for _, v := range vals {
+		if x.val != v {
+			return false
+		}
+		x = x.levels[0].next
+	}
+	return true
+}
+
+// IsSub returns whether it's a part of set t
+func (s *UnsafeSortedSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	return isSub[T](s, t)
+}
+
+// Union unions with set t and returns a new UnsafeSortedSet
+func (s *UnsafeSortedSet[T]) Union(t ReadableSet[T]) *UnsafeSortedSet[T] {
+	if isNil(t) {
+		return s.Copy()
+	}
+	if r, ok := s.merge(t, true, true, true); ok {
+		return r
+	}
+	r := s.Copy()
+	r.Add(t.ToList()...)
+	return r
+}
+
+// Subtract returns a new UnsafeSortedSet whose elements exist in itself but don't exist in set t
+func (s *UnsafeSortedSet[T]) Subtract(t ReadableSet[T]) *UnsafeSortedSet[T] {
+	if isNil(t) || t.Length() == 0 {
+		return s.Copy()
+	}
+	if r, ok := s.merge(t, true, false, false); ok {
+		return r
+	}
+
+	t = untouched(t)
+	b := newSkipListBuilder[T](s.list.cmp)
+	for x := s.list.first(); x != nil; x = x.levels[0].next {
+		if !t.Has(x.val) {
+			b.push(x.val)
+		}
+	}
+	return &UnsafeSortedSet[T]{list: b.build()}
+}
+
+// Intersect returns a new UnsafeSortedSet whose elements exist in both sets
+func (s *UnsafeSortedSet[T]) Intersect(t ReadableSet[T]) *UnsafeSortedSet[T] {
+	if isNil(t) || s.Length() == 0 || t.Length() == 0 {
+		return s.empty()
+	}
+	if r, ok := s.merge(t, false, true, false); ok {
+		return r
+	}
+
+	t = untouched(t)
+	if s.Length() <= t.Length() {
+		// elements of s are visited in order, so they can be appended directly
+		b := newSkipListBuilder[T](s.list.cmp)
+		for x := s.list.first(); x != nil; x = x.levels[0].next {
+			if t.Has(x.val) {
+				b.push(x.val)
+			}
+		}
+		return &UnsafeSortedSet[T]{list: b.build()}
+	}
+
+	r := s.empty()
+	for _, v := range t.ToList() {
+		if s.Has(v) {
+			r.list.insert(v)
+		}
+	}
+	return r
+}
+
+// Complement returns a new UnsafeSortedSet whose elements only exist in one set
+func (s *UnsafeSortedSet[T]) Complement(t ReadableSet[T]) *UnsafeSortedSet[T] {
+	if isNil(t) || s.Length() == 0 || t.Length() == 0 {
+		return s.Union(t)
+	}
+	if r, ok := s.merge(t, true, false, true); ok {
+		return r
+	}
+
+	r := s.Union(t)
+	r.Delete(s.Intersect(t).ToList()...)
+	return r
+}
+
+// UnionWith adds elements of set t, and returns the number of added elements
+func (s *UnsafeSortedSet[T]) UnionWith(t ReadableSet[T]) int {
+	return unionWith[T](s, t)
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *UnsafeSortedSet[T]) IntersectWith(t ReadableSet[T]) int {
+	n := s.list.length
+	if isNil(t) {
+		s.Clear()
+		return n
+	}
+	t = untouched(t)
+	// elements of s are visited in order, so the rest can be appended directly
+	b := newSkipListBuilder[T](s.list.cmp)
+	for x := s.list.first(); x != nil; x = x.levels[0].next {
+		if t.Has(x.val) {
+			b.push(x.val)
+		}
+	}
+	if l := b.build(); l.length != n {
+		*s.list = *l
+	}
+	return n - s.list.length
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *UnsafeSortedSet[T]) SubtractWith(t ReadableSet[T]) int {
+	return subtractWith[T](s, t)
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *UnsafeSortedSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	return symmetricDifferenceWith[T](s, t)
+}
+
+// merge merges with t in linear time if t is a sorted set in the same order, see mergeSkipList.
+// ok is false if t can't be merged
+func (s *UnsafeSortedSet[T]) merge(t ReadableSet[T], keepS, keepBoth, keepT bool) (r *UnsafeSortedSet[T], ok bool) {
+	var vals []T
+	switch o := t.(type) {
+	case *UnsafeSortedSet[T]:
+		vals = o.list.toList()
+	default:
+		return nil, false
+	}
+	if !isSortedBy(vals, s.list.cmp) {
+		// t is ordered by another comparator
+		return nil, false
+	}
+	return &UnsafeSortedSet[T]{list: mergeSkipList(s.list, vals, keepS, keepBoth, keepT)}, true
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *UnsafeSortedSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	return relation[T](s, t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *UnsafeSortedSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *UnsafeSortedSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *UnsafeSortedSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *UnsafeSortedSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	return disjoint[T](s, t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *UnsafeSortedSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
+}
@@ -0,0 +1,264 @@
+package goset
+
+// UnsafeSet has the same functions with Set but without locks, so it's faster but not goroutine safe.
+// Use Set or wrap it by Synchronized if it's shared among goroutines
+type UnsafeSet[T comparable] struct {
+	data map[T]struct{}
+}
+
+// NewUnsafeSet creates a new UnsafeSet
+func NewUnsafeSet[T comparable](vals ...T) *UnsafeSet[T] {
+	s := &UnsafeSet[T]{data: make(map[T]struct{}, len(vals))}
+	s.Add(vals...)
+	return s
+}
+
+// Add adds elements
+func (s *UnsafeSet[T]) Add(vals ...T) {
+	if s.data == nil {
+		s.data = make(map[T]struct{}, len(vals))
+	}
+	for _, v := range vals {
+		s.data[v] = struct{}{}
+	}
+}
+
+// Delete deletes elements
+func (s *UnsafeSet[T]) Delete(vals ...T) {
+	for _, v := range vals {
+		delete(s.data, v)
+	}
+}
+
+// Clear clears all elements
+func (s *UnsafeSet[T]) Clear() {
+	s.data = make(map[T]struct{})
+}
+
+// Copy returns a deep copy of itself
+func (s *UnsafeSet[T]) Copy() *UnsafeSet[T] {
+	data := make(map[T]struct{}, len(s.data))
+	for v := range s.data {
+		data[v] = struct{}{}
+	}
+	return &UnsafeSet[T]{data: data}
+}
+
+// Length returns UnsafeSet length
+func (s *UnsafeSet[T]) Length() int {
+	return len(s.data)
+}
+
+// Has returns whether v exists in UnsafeSet
+func (s *UnsafeSet[T]) Has(v T) bool {
+	_, ok := s.data[v]
+	return ok
+}
+
+// ToList returns data slice
+func (s *UnsafeSet[T]) ToList() []T {
+	r := make([]T, 0, len(s.data))
+	for v := range s.data {
+		r = append(r, v)
+	}
+	return r
+}
+
+// Equals returns whether UnsafeSet s has the same members with set t
+func (s *UnsafeSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*UnsafeSet[T]); ok {
+		return len(s.data) == len(o.data) && s.IsSub(o)
+	}
+	return equals[T](s, t)
+}
+
+// IsSub returns whether it's a part of set t
+func (s *UnsafeSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*UnsafeSet[T]); ok {
+		// look up the maps directly without copying elements
+		if len(s.data) > len(o.data) {
+			return false
+		}
+		for v := range s.data {
+			if _, ok := o.data[v]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return isSub[T](s, t)
+}
+
+// Union unions with set t and returns a new UnsafeSet
+func (s *UnsafeSet[T]) Union(t ReadableSet[T]) *UnsafeSet[T] {
+	r := s.Copy()
+	if isNil(t) {
+		return r
+	}
+	r.Add(t.ToList()...)
+	return r
+}
+
+// Intersect returns a new UnsafeSet whose elements exist in both sets
+func (s *UnsafeSet[T]) Intersect(t ReadableSet[T]) *UnsafeSet[T] {
+	r := NewUnsafeSet[T]()
+	if isNil(t) {
+		return r
+	}
+	t = untouched(t)
+	if s.Length() >= t.Length() {
+		for _, v := range t.ToList() {
+			if s.Has(v) {
+				r.Add(v)
+			}
+		}
+	} else {
+		for v := range s.data {
+			if t.Has(v) {
+				r.Add(v)
+			}
+		}
+	}
+	return r
+}
+
+// Subtract returns a new UnsafeSet whose elements exist in itself but don't exist in set t
+func (s *UnsafeSet[T]) Subtract(t ReadableSet[T]) *UnsafeSet[T] {
+	if isNil(t) {
+		return s.Copy()
+	}
+	t = untouched(t)
+	r := NewUnsafeSet[T]()
+	for v := range s.data {
+		if !t.Has(v) {
+			r.Add(v)
+		}
+	}
+	return r
+}
+
+// Complement returns a new UnsafeSet whose elements only exist in one set
+func (s *UnsafeSet[T]) Complement(t ReadableSet[T]) *UnsafeSet[T] {
+	r := s.Subtract(t)
+	if isNil(t) {
+		return r
+	}
+	for _, v := range t.ToList() {
+		if !s.Has(v) {
+			r.Add(v)
+		}
+	}
+	return r
+}
+
+// UnionWith adds elements of set t, and returns the number of added elements
+func (s *UnsafeSet[T]) UnionWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	n := len(s.data)
+	if o, ok := t.(*UnsafeSet[T]); ok {
+		if s.data == nil {
+			s.data = make(map[T]struct{}, len(o.data))
+		}
+		for v := range o.data {
+			s.data[v] = struct{}{}
+		}
+	} else {
+		s.Add(t.ToList()...)
+	}
+	return len(s.data) - n
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *UnsafeSet[T]) IntersectWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		n := len(s.data)
+		s.Clear()
+		return n
+	}
+	t = untouched(t)
+	var n int
+	for v := range s.data {
+		if !t.Has(v) {
+			delete(s.data, v)
+			n++
+		}
+	}
+	return n
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *UnsafeSet[T]) SubtractWith(t ReadableSet[T]) int {
+	if isNil(t) {
+		return 0
+	}
+	n := len(s.data)
+	if o, ok := t.(*UnsafeSet[T]); ok {
+		for v := range o.data {
+			delete(s.data, v)
+		}
+	} else {
+		s.Delete(t.ToList()...)
+	}
+	return n - len(s.data)
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *UnsafeSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	return symmetricDifferenceWith[T](s, t)
+}
+
+// lockFree returns t itself if it has no lock, otherwise returns a snapshot of t,
+// so that t can be read while holding other locks
+func lockFree[T comparable](t ReadableSet[T]) ReadableSet[T] {
+	if isNil(t) {
+		return nil
+	}
+	switch o := t.(type) {
+	case *UnsafeSet[T], *UnsafeSortedSet[T], *ImmutableSet[T], *SetSnapshot[T]:
+		return t
+	case *SortedSet[T]:
+		return o.unsafeCopy()
+	default:
+		return NewUnsafeSet(t.ToList()...)
+	}
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *UnsafeSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	return relation[T](s, t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *UnsafeSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
+}
+
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *UnsafeSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
+}
+
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *UnsafeSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
+}
+
+// IsDisjoint returns whether it has no common element with set t
+func (s *UnsafeSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	return disjoint[T](s, t)
+}
+
+// Intersects returns whether it has any common element with set t
+func (s *UnsafeSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
+}
@@ -0,0 +1,161 @@
+package goset
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestUnsafeSet(t *testing.T) {
+	s := NewUnsafeSet(1, -1, 5)
+	if r := s.Length(); r != 3 {
+		t.Fatalf("s.Length() got unexpected %d", r)
+	}
+	if !s.Has(-1) || s.Has(2) {
+		t.Fatalf("s.Has() got unexpected result")
+	}
+	if r := s.Union(NewSet(10, 3)); !r.Equals(NewSet(1, -1, 5, 10, 3)) {
+		t.Fatalf("s.Union() got unexpected %v", r.ToList())
+	}
+	if r := s.Intersect(NewFifoSet(1, 2)); !r.Equals(NewSet(1)) {
+		t.Fatalf("s.Intersect() got unexpected %v", r.ToList())
+	}
+	if r := s.Subtract(NewUnsafeSet(1)); !r.Equals(NewSet(-1, 5)) {
+		t.Fatalf("s.Subtract() got unexpected %v", r.ToList())
+	}
+	if r := s.Complement(NewSortedSet(1, 2)); !r.Equals(NewSet(-1, 5, 2)) {
+		t.Fatalf("s.Complement() got unexpected %v", r.ToList())
+	}
+	if r := s.Complement(s); r.Length() != 0 {
+		t.Fatalf("s.Complement(s) got unexpected %v", r.ToList())
+	}
+	s.Delete(1)
+	s.Add(6)
+	if !s.Equals(NewSet(-1, 5, 6)) {
+		t.Fatalf("s.Delete(1) and s.Add(6) got unexpected %v", s.ToList())
+	}
+	s.Clear()
+	if s.Length() != 0 {
+		t.Fatalf("s.Clear() got unexpected %v", s.ToList())
+	}
+
+	var zero UnsafeSet[string]
+	if zero.Has("a") || zero.Length() != 0 {
+		t.Fatalf("zero UnsafeSet got unexpected %v", zero.ToList())
+	}
+	zero.Add("a")
+	if !zero.Has("a") {
+		t.Fatalf("zero.Add(\"a\") got unexpected %v", zero.ToList())
+	}
+}
+
+func TestUnsafeSortedSet(t *testing.T) {
+	s := NewUnsafeSortedSet(5, 3, 9, 1)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1, 3, 5, 9}) {
+		t.Fatalf("s.ToList() got unexpected %v", r)
+	}
+	if r := s.Union(NewSortedSet(4, 3)).ToList(); !reflect.DeepEqual(r, []int{1, 3, 4, 5, 9}) {
+		t.Fatalf("s.Union() got unexpected %v", r)
+	}
+	if r := s.Intersect(NewSet(9, 3, 7)).ToList(); !reflect.DeepEqual(r, []int{3, 9}) {
+		t.Fatalf("s.Intersect() got unexpected %v", r)
+	}
+	if r, ok := s.Rank(5); !ok || r != 2 {
+		t.Fatalf("s.Rank(5) got unexpected %d %t", r, ok)
+	}
+	if r, ok := s.Ceiling(6); !ok || r != 9 {
+		t.Fatalf("s.Ceiling(6) got unexpected %d %t", r, ok)
+	}
+}
+
+func TestSynchronized(t *testing.T) {
+	s := Synchronized[int](NewUnsafeSortedSet[int]())
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Add(g*100 + i)
+				s.Has(i)
+				s.Equals(s)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	r := s.ToList()
+	if len(r) != 800 || r[0] != 0 || r[799] != 799 {
+		t.Fatalf("s.ToList() got unexpected %d elements", len(r))
+	}
+	if !s.IsSub(NewSet(r...)) {
+		t.Fatalf("s.IsSub() got unexpected false")
+	}
+}
+
+func TestSynchronizedAlgebra(t *testing.T) {
+	s := Synchronized[int](NewUnsafeSortedSet(3, 1, 2))
+	c := s.Copy()
+	s.Add(4)
+	if r := c.ToList(); !reflect.DeepEqual(r, []int{1, 2, 3}) {
+		t.Fatalf("s.Copy() got unexpected %v", r)
+	}
+	if r := s.Union(NewSet(5, 0)); !reflect.DeepEqual(r.ToList(), []int{0, 1, 2, 3, 4, 5}) {
+		t.Fatalf("s.Union() got unexpected %v", r.ToList())
+	}
+	if r := s.Intersect(NewFifoSet(4, 2, 9)); !reflect.DeepEqual(r.ToList(), []int{2, 4}) {
+		t.Fatalf("s.Intersect() got unexpected %v", r.ToList())
+	}
+	if r := s.Subtract(s); r.Length() != 0 {
+		t.Fatalf("s.Subtract(s) got unexpected %v", r.ToList())
+	}
+	if r := s.Complement(NewSet(4, 5)); !reflect.DeepEqual(r.ToList(), []int{1, 2, 3, 5}) {
+		t.Fatalf("s.Complement() got unexpected %v", r.ToList())
+	}
+
+	// sets of other kinds are copied into an UnsafeSet
+	f := Synchronized[int](NewFifoSet(1, 2))
+	if r := f.Union(NewSet(3)); !r.Equals(NewSet(1, 2, 3)) {
+		t.Fatalf("f.Union() got unexpected %v", r.ToList())
+	}
+	if _, ok := f.Copy().s.(*UnsafeSet[int]); !ok {
+		t.Fatalf("f.Copy() got unexpected %T", f.Copy().s)
+	}
+}
+
+func BenchmarkUnsafeSet(b *testing.B) {
+	const n = 1 << 16
+	run := func(b *testing.B, s Interface[int]) {
+		for i := 0; i < b.N; i++ {
+			s.Add(i % n)
+			s.Has(i % n)
+		}
+	}
+	b.Run("Set", func(b *testing.B) {
+		run(b, NewSet[int]())
+	})
+	b.Run("UnsafeSet", func(b *testing.B) {
+		run(b, NewUnsafeSet[int]())
+	})
+	b.Run("Synchronized", func(b *testing.B) {
+		run(b, Synchronized[int](NewUnsafeSet[int]()))
+	})
+}
+
+func BenchmarkUnsafeSortedSet(b *testing.B) {
+	const n = 1 << 16
+	vals := rand.Perm(n)
+	run := func(b *testing.B, s Interface[int]) {
+		for i := 0; i < b.N; i++ {
+			s.Add(vals[i%n])
+			s.Has(vals[i%n])
+		}
+	}
+	b.Run("SortedSet", func(b *testing.B) {
+		run(b, NewSortedSet[int]())
+	})
+	b.Run("UnsafeSortedSet", func(b *testing.B) {
+		run(b, NewUnsafeSortedSet[int]())
+	})
+}
@@ -1,49 +1,276 @@
 package goset
 
 import (
+	"sync"
+
 	cmp "github.com/visforest/goset/v2/compare"
 )
 
+// NewSortedSet creates a new SortedSet whose elements are ordered by compare.Compare
 func NewSortedSet[T cmp.Ordered](vals ...T) *SortedSet[T] {
-	s := &SortedSet[T]{newLinearSet[T](addSorted[T])}
-	s.Add(vals...)
-	return s
+	return NewSortedSetFunc[T](cmp.Compare[T], vals...)
+}
+
+// NewSortedSetFunc creates a new SortedSet whose elements are ordered by cmp,
+// which returns a negative number when a < b, a positive number when a > b and zero when a == b.
+// Elements that cmp regards as equal are regarded as the same element
+//
+// for example, a SortedSet of strings in desc order:
+// NewSortedSetFunc(func(a, b string) int { return strings.Compare(b, a) })
+func NewSortedSetFunc[T comparable](cmp func(a, b T) int, vals ...T) *SortedSet[T] {
+	return &SortedSet[T]{u: *NewUnsafeSortedSetFunc[T](cmp, vals...)}
 }
 
-// SortedSet is a set whose elements are stored in asc order
-type SortedSet[T cmp.Ordered] struct {
-	*linearSet[T]
+// SortedSet is a set whose elements are stored in asc order of its comparator.
+// It's backed by a skip list, so that Add, Delete and Has take O(log n) time.
+// SortedSet is goroutine safe, see UnsafeSortedSet for the version without locks
+type SortedSet[T comparable] struct {
+	m sync.RWMutex
+	u UnsafeSortedSet[T]
 }
 
+// Add adds elements
 func (s *SortedSet[T]) Add(vals ...T) {
-	addSorted(s.linearSet, vals...)
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	s.u.Add(vals...)
+}
+
+// Delete deletes elements
+func (s *SortedSet[T]) Delete(vals ...T) {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	s.u.Delete(vals...)
+}
+
+// Clear clears all elements
+func (s *SortedSet[T]) Clear() {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	s.u.Clear()
+}
+
+// Length returns SortedSet length
+func (s *SortedSet[T]) Length() int {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Length()
+}
+
+// Has returns whether v exists in SortedSet
+func (s *SortedSet[T]) Has(v T) bool {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Has(v)
+}
+
+// ToList returns data slice in asc order
+func (s *SortedSet[T]) ToList() []T {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.ToList()
 }
 
 // Copy returns a deep copy of itself
 func (s *SortedSet[T]) Copy() *SortedSet[T] {
-	return &SortedSet[T]{s.linearSet.copy(addSorted[T])}
+	return &SortedSet[T]{u: *s.unsafeCopy()}
+}
+
+func (s *SortedSet[T]) unsafeCopy() *UnsafeSortedSet[T] {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Copy()
+}
+
+// Equals returns whether SortedSet s has the same members with set t
+func (s *SortedSet[T]) Equals(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*SortedSet[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
+
+		return s.u.Equals(&o.u)
+	}
+	t = lockFree(t)
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Equals(t)
+}
+
+// EqualsOrdered returns whether SortedSet s has the same elements with set t in the same order,
+// elements of t are in the order of its ToList
+func (s *SortedSet[T]) EqualsOrdered(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*SortedSet[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
+
+		return s.u.EqualsOrdered(&o.u)
+	}
+	// t is read before locking s, so that no lock is held while reading t
+	vals := t.ToList()
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.equalsList(vals)
+}
+
+// IsSub returns whether it's a part of set t
+func (s *SortedSet[T]) IsSub(t ReadableSet[T]) bool {
+	if isNil(t) {
+		return false
+	}
+	if o, ok := t.(*SortedSet[T]); ok && o != nil {
+		unlock := rlockBoth(&s.m, &o.m)
+		defer unlock()
+
+		return s.u.IsSub(&o.u)
+	}
+	t = lockFree(t)
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.IsSub(t)
+}
+
+// rlock read locks s and t if t is a SortedSet, otherwise it read locks s and replaces t with its lock free version.
+// It returns the function to unlock
+func (s *SortedSet[T]) rlock(t *ReadableSet[T]) (unlock func()) {
+	if o, ok := (*t).(*SortedSet[T]); ok && o != nil {
+		*t = &o.u
+		return rlockBoth(&s.m, &o.m)
+	}
+	*t = lockFree(*t)
+	s.m.RLock()
+	return s.m.RUnlock
+}
+
+// Union unions with set t and returns a new SortedSet.
+// If t is a SortedSet in the same order, they are merged in linear time
+func (s *SortedSet[T]) Union(t ReadableSet[T]) *SortedSet[T] {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return &SortedSet[T]{u: *s.u.Union(t)}
+}
+
+// Subtract returns a new SortedSet whose elements exist in itself but don't exist in set t.
+// If t is a SortedSet in the same order, they are merged in linear time
+func (s *SortedSet[T]) Subtract(t ReadableSet[T]) *SortedSet[T] {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return &SortedSet[T]{u: *s.u.Subtract(t)}
+}
+
+// Intersect returns a new SortedSet whose elements exist in both sets.
+// If t is a SortedSet in the same order, they are merged in linear time
+func (s *SortedSet[T]) Intersect(t ReadableSet[T]) *SortedSet[T] {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return &SortedSet[T]{u: *s.u.Intersect(t)}
+}
+
+// Complement returns a new SortedSet whose elements only exist in one set.
+// If t is a SortedSet in the same order, they are merged in linear time
+func (s *SortedSet[T]) Complement(t ReadableSet[T]) *SortedSet[T] {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return &SortedSet[T]{u: *s.u.Complement(t)}
 }
 
-func (s *SortedSet[T]) Equals(t *SortedSet[T]) bool {
-	return s.linearSet.Equals(t.linearSet)
+// lock write locks s and read locks t if t is a SortedSet, otherwise it write locks s and replaces t with its lock free version.
+// It returns the function to unlock
+func (s *SortedSet[T]) lock(t *ReadableSet[T]) (unlock func()) {
+	if o, ok := (*t).(*SortedSet[T]); ok && o != nil {
+		*t = &o.u
+		return lockBoth(&s.m, &o.m)
+	}
+	*t = lockFree(*t)
+	s.m.Lock()
+	return s.m.Unlock
 }
 
-func (s *SortedSet[T]) IsSub(t *SortedSet[T]) bool {
-	return s.linearSet.IsSub(t.linearSet)
+// UnionWith adds elements of set t, and returns the number of added elements
+func (s *SortedSet[T]) UnionWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	return s.u.UnionWith(t)
+}
+
+// IntersectWith deletes elements which don't exist in set t, and returns the number of deleted elements
+func (s *SortedSet[T]) IntersectWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	return s.u.IntersectWith(t)
+}
+
+// SubtractWith deletes elements which exist in set t, and returns the number of deleted elements
+func (s *SortedSet[T]) SubtractWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	return s.u.SubtractWith(t)
+}
+
+// SymmetricDifferenceWith deletes elements which exist in set t and adds the others of t,
+// so that it keeps elements only existing in one set. It returns the number of deleted and added elements
+func (s *SortedSet[T]) SymmetricDifferenceWith(t ReadableSet[T]) int {
+	unlock := s.lock(&t)
+	defer unlock()
+
+	return s.u.SymmetricDifferenceWith(t)
+}
+
+// Relation returns the relation between it and set t in one pass, nil t is treated as an empty set
+func (s *SortedSet[T]) Relation(t ReadableSet[T]) SetRelation {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return s.u.Relation(t)
+}
+
+// IsSuperset returns whether every element of set t exists in it
+func (s *SortedSet[T]) IsSuperset(t ReadableSet[T]) bool {
+	r := s.Relation(t)
+	return r == RelationEqual || r == RelationSuperset
 }
 
-func (s *SortedSet[T]) Union(t *SortedSet[T]) *SortedSet[T] {
-	return &SortedSet[T]{s.linearSet.union(t.linearSet, addSorted[T])}
+// IsProperSub returns whether it's a part of set t but doesn't equal t
+func (s *SortedSet[T]) IsProperSub(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSubset
 }
 
-func (s *SortedSet[T]) Subtract(t *SortedSet[T]) *SortedSet[T] {
-	return &SortedSet[T]{s.linearSet.subtract(t.linearSet, addSorted[T])}
+// IsProperSuperset returns whether every element of set t exists in it but it doesn't equal t
+func (s *SortedSet[T]) IsProperSuperset(t ReadableSet[T]) bool {
+	return s.Relation(t) == RelationSuperset
 }
 
-func (s *SortedSet[T]) Intersect(t *SortedSet[T]) *SortedSet[T] {
-	return &SortedSet[T]{s.linearSet.intersect(t.linearSet, addSorted[T])}
+// IsDisjoint returns whether it has no common element with set t
+func (s *SortedSet[T]) IsDisjoint(t ReadableSet[T]) bool {
+	unlock := s.rlock(&t)
+	defer unlock()
+
+	return s.u.IsDisjoint(t)
 }
 
-func (s *SortedSet[T]) Complement(t *SortedSet[T]) *SortedSet[T] {
-	return &SortedSet[T]{s.linearSet.complement(t.linearSet, addSorted[T])}
+// Intersects returns whether it has any common element with set t
+func (s *SortedSet[T]) Intersects(t ReadableSet[T]) bool {
+	return !s.IsDisjoint(t)
 }
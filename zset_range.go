@@ -0,0 +1,206 @@
+package goset
+
+import "fmt"
+
+// Min returns the min element, ok is false if UnsafeSortedSet is empty
+func (s *UnsafeSortedSet[T]) Min() (v T, ok bool) {
+	return nodeVal(s.list.first())
+}
+
+// Max returns the max element, ok is false if UnsafeSortedSet is empty
+func (s *UnsafeSortedSet[T]) Max() (v T, ok bool) {
+	return nodeVal(s.list.tail)
+}
+
+// Floor returns the greatest element less than or equal to v, ok is false if there is no such element
+func (s *UnsafeSortedSet[T]) Floor(v T) (r T, ok bool) {
+	n, _ := s.list.bound(v, true)
+	return nodeVal(n)
+}
+
+// Ceiling returns the least element greater than or equal to v, ok is false if there is no such element
+func (s *UnsafeSortedSet[T]) Ceiling(v T) (r T, ok bool) {
+	_, n := s.list.bound(v, false)
+	return nodeVal(n)
+}
+
+// Lower returns the greatest element strictly less than v, ok is false if there is no such element
+func (s *UnsafeSortedSet[T]) Lower(v T) (r T, ok bool) {
+	n, _ := s.list.bound(v, false)
+	return nodeVal(n)
+}
+
+// Higher returns the least element strictly greater than v, ok is false if there is no such element
+func (s *UnsafeSortedSet[T]) Higher(v T) (r T, ok bool) {
+	_, n := s.list.bound(v, true)
+	return nodeVal(n)
+}
+
+// Range returns a new UnsafeSortedSet whose elements are between lo and hi,
+// loInclusive and hiInclusive determine whether lo and hi themselves are included
+//
+// for example:
+// var a=NewUnsafeSortedSet(1,2,3,4,5)
+// a.Range(2,4,true,false) returns {2,3}
+func (s *UnsafeSortedSet[T]) Range(lo, hi T, loInclusive, hiInclusive bool) *UnsafeSortedSet[T] {
+	b := newSkipListBuilder[T](s.list.cmp)
+	_, x := s.list.bound(lo, !loInclusive)
+	for ; x != nil; x = x.levels[0].next {
+		if c := s.list.cmp(x.val, hi); c > 0 || c == 0 && !hiInclusive {
+			break
+		}
+		b.push(x.val)
+	}
+	return &UnsafeSortedSet[T]{list: b.build()}
+}
+
+// DeleteRange deletes elements between lo and hi inclusively, and returns the number of deleted elements
+func (s *UnsafeSortedSet[T]) DeleteRange(lo, hi T) int {
+	return s.list.removeRange(lo, hi)
+}
+
+// Rank returns the 0-based position of v in asc order, ok is false if v doesn't exist
+func (s *UnsafeSortedSet[T]) Rank(v T) (int, bool) {
+	r := s.list.rank(v)
+	return r, r >= 0
+}
+
+// At returns the k-th smallest element, k is 0-based.
+// It panics if k is out of range
+func (s *UnsafeSortedSet[T]) At(k int) T {
+	n := s.list.nodeAt(k)
+	if n == nil {
+		panic(fmt.Sprintf("goset: index %d out of range [0:%d]", k, s.list.length))
+	}
+	return n.val
+}
+
+// Slice returns elements whose positions are in [from, to) in asc order,
+// from and to are clamped to [0, Length()]
+//
+// for example:
+// var a=NewUnsafeSortedSet(5,1,4,2,3)
+// a.Slice(1,3) returns [2,3]
+func (s *UnsafeSortedSet[T]) Slice(from, to int) []T {
+	if from < 0 {
+		from = 0
+	}
+	if to > s.list.length {
+		to = s.list.length
+	}
+	if from >= to {
+		return nil
+	}
+	r := make([]T, 0, to-from)
+	for x := s.list.nodeAt(from); len(r) < to-from; x = x.levels[0].next {
+		r = append(r, x.val)
+	}
+	return r
+}
+
+// nodeVal returns the value of n, ok is false if n is nil
+func nodeVal[T any](n *skipNode[T]) (v T, ok bool) {
+	if n == nil {
+		return v, false
+	}
+	return n.val, true
+}
+
+// Min returns the min element, ok is false if SortedSet is empty
+func (s *SortedSet[T]) Min() (v T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Min()
+}
+
+// Max returns the max element, ok is false if SortedSet is empty
+func (s *SortedSet[T]) Max() (v T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Max()
+}
+
+// Floor returns the greatest element less than or equal to v, ok is false if there is no such element
+func (s *SortedSet[T]) Floor(v T) (r T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Floor(v)
+}
+
+// Ceiling returns the least element greater than or equal to v, ok is false if there is no such element
+func (s *SortedSet[T]) Ceiling(v T) (r T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Ceiling(v)
+}
+
+// Lower returns the greatest element strictly less than v, ok is false if there is no such element
+func (s *SortedSet[T]) Lower(v T) (r T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Lower(v)
+}
+
+// Higher returns the least element strictly greater than v, ok is false if there is no such element
+func (s *SortedSet[T]) Higher(v T) (r T, ok bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Higher(v)
+}
+
+// Range returns a new SortedSet whose elements are between lo and hi,
+// loInclusive and hiInclusive determine whether lo and hi themselves are included
+//
+// for example:
+// var a=NewSortedSet(1,2,3,4,5)
+// a.Range(2,4,true,false) returns {2,3}
+func (s *SortedSet[T]) Range(lo, hi T, loInclusive, hiInclusive bool) *SortedSet[T] {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return &SortedSet[T]{u: *s.u.Range(lo, hi, loInclusive, hiInclusive)}
+}
+
+// DeleteRange deletes elements between lo and hi inclusively, and returns the number of deleted elements
+func (s *SortedSet[T]) DeleteRange(lo, hi T) int {
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	return s.u.DeleteRange(lo, hi)
+}
+
+// Rank returns the 0-based position of v in asc order, ok is false if v doesn't exist
+func (s *SortedSet[T]) Rank(v T) (int, bool) {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Rank(v)
+}
+
+// At returns the k-th smallest element, k is 0-based.
+// It panics if k is out of range
+func (s *SortedSet[T]) At(k int) T {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.At(k)
+}
+
+// Slice returns elements whose positions are in [from, to) in asc order,
+// from and to are clamped to [0, Length()]
+//
+// for example:
+// var a=NewSortedSet(5,1,4,2,3)
+// a.Slice(1,3) returns [2,3]
+func (s *SortedSet[T]) Slice(from, to int) []T {
+	defer s.m.RUnlock()
+	s.m.RLock()
+
+	return s.u.Slice(from, to)
+}
@@ -0,0 +1,476 @@
+package goset
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	cmp "github.com/visforest/goset/v2/compare"
+)
+
+// checkSkipList verifies order, backward links and spans of a skipList
+func checkSkipList[T any](t *testing.T, l *skipList[T]) {
+	t.Helper()
+	var n int
+	var pre *skipNode[T]
+	for x := l.first(); x != nil; x = x.levels[0].next {
+		if x.pre != pre {
+			t.Fatalf("node %d has unexpected pre", n)
+		}
+		if pre != nil && l.cmp(pre.val, x.val) >= 0 {
+			t.Fatalf("node %d is out of order", n)
+		}
+		pre = x
+		n++
+	}
+	if n != l.length || l.tail != pre {
+		t.Fatalf("skipList has %d nodes, length %d", n, l.length)
+	}
+	for i := 0; i < l.level; i++ {
+		var rank int
+		for x := l.head; x.levels[i].next != nil; x = x.levels[i].next {
+			next := x.levels[i].next
+			var steps int
+			for y := x; y != next; y = y.levels[0].next {
+				steps++
+			}
+			if steps != x.levels[i].span {
+				t.Fatalf("level %d at rank %d got unexpected span %d, want %d", i, rank, x.levels[i].span, steps)
+			}
+			rank += steps
+		}
+	}
+}
+
+func TestSortedSet(t *testing.T) {
+	s := NewSortedSet(5, 7, 10, 3, -1, 7, 0, 9, 3)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{-1, 0, 3, 5, 7, 9, 10}) {
+		t.Fatalf("s.ToList() got unexpected %v", r)
+	}
+	if !s.Has(9) || s.Has(8) {
+		t.Fatalf("s.Has() got unexpected result")
+	}
+	s.Delete(-1, 9, 8)
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{0, 3, 5, 7, 10}) {
+		t.Fatalf("s.Delete(-1, 9, 8) got unexpected %v", s.ToList())
+	}
+	checkSkipList(t, s.u.list)
+
+	o := NewSortedSet(3, 4, 10, 11)
+	if r := s.Union(o).ToList(); !reflect.DeepEqual(r, []int{0, 3, 4, 5, 7, 10, 11}) {
+		t.Fatalf("s.Union(o) got unexpected %v", r)
+	}
+	if r := s.Intersect(o).ToList(); !reflect.DeepEqual(r, []int{3, 10}) {
+		t.Fatalf("s.Intersect(o) got unexpected %v", r)
+	}
+	if r := s.Subtract(o).ToList(); !reflect.DeepEqual(r, []int{0, 5, 7}) {
+		t.Fatalf("s.Subtract(o) got unexpected %v", r)
+	}
+	if r := s.Complement(o).ToList(); !reflect.DeepEqual(r, []int{0, 4, 5, 7, 11}) {
+		t.Fatalf("s.Complement(o) got unexpected %v", r)
+	}
+	if r := s.Copy(); !r.Equals(s) {
+		t.Fatalf("s.Copy() got unexpected %v", r.ToList())
+	}
+	checkSkipList(t, s.Copy().u.list)
+	s.Clear()
+	if s.Length() != 0 || s.ToList() != nil {
+		t.Fatalf("s.Clear() got unexpected %v", s.ToList())
+	}
+}
+
+func TestSortedSetRandom(t *testing.T) {
+	s := NewSortedSet[int]()
+	m := make(map[int]struct{})
+	for i := 0; i < 20000; i++ {
+		v := rand.Intn(2000)
+		if rand.Intn(3) == 0 {
+			s.Delete(v)
+			delete(m, v)
+		} else {
+			s.Add(v)
+			m[v] = struct{}{}
+		}
+	}
+	checkSkipList(t, s.u.list)
+
+	want := make([]int, 0, len(m))
+	for v := range m {
+		want = append(want, v)
+	}
+	sort.Ints(want)
+	if r := s.ToList(); !reflect.DeepEqual(r, want) {
+		t.Fatalf("s.ToList() got unexpected %v", r)
+	}
+}
+
+func TestSortedSetRange(t *testing.T) {
+	s := NewSortedSet(1, 3, 5, 7, 9)
+	if v, ok := s.Min(); !ok || v != 1 {
+		t.Fatalf("s.Min() got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Max(); !ok || v != 9 {
+		t.Fatalf("s.Max() got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Floor(5); !ok || v != 5 {
+		t.Fatalf("s.Floor(5) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Floor(4); !ok || v != 3 {
+		t.Fatalf("s.Floor(4) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Floor(0); ok {
+		t.Fatalf("s.Floor(0) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Ceiling(5); !ok || v != 5 {
+		t.Fatalf("s.Ceiling(5) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Ceiling(10); ok {
+		t.Fatalf("s.Ceiling(10) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Lower(5); !ok || v != 3 {
+		t.Fatalf("s.Lower(5) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Lower(1); ok {
+		t.Fatalf("s.Lower(1) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Higher(5); !ok || v != 7 {
+		t.Fatalf("s.Higher(5) got unexpected %d %t", v, ok)
+	}
+	if v, ok := s.Higher(9); ok {
+		t.Fatalf("s.Higher(9) got unexpected %d %t", v, ok)
+	}
+	if r := s.Range(3, 7, true, true).ToList(); !reflect.DeepEqual(r, []int{3, 5, 7}) {
+		t.Fatalf("s.Range(3, 7, true, true) got unexpected %v", r)
+	}
+	if r := s.Range(3, 7, false, false).ToList(); !reflect.DeepEqual(r, []int{5}) {
+		t.Fatalf("s.Range(3, 7, false, false) got unexpected %v", r)
+	}
+	if r := s.Range(2, 8, false, true).ToList(); !reflect.DeepEqual(r, []int{3, 5, 7}) {
+		t.Fatalf("s.Range(2, 8, false, true) got unexpected %v", r)
+	}
+	if r := s.Range(7, 3, true, true); r.Length() != 0 {
+		t.Fatalf("s.Range(7, 3, true, true) got unexpected %v", r.ToList())
+	}
+	if n := s.DeleteRange(2, 7); n != 3 {
+		t.Fatalf("s.DeleteRange(2, 7) got unexpected %d", n)
+	}
+	if r := s.ToList(); !reflect.DeepEqual(r, []int{1, 9}) {
+		t.Fatalf("s.DeleteRange(2, 7) got unexpected %v", r)
+	}
+	checkSkipList(t, s.u.list)
+
+	var e = NewSortedSet[int]()
+	if v, ok := e.Min(); ok {
+		t.Fatalf("e.Min() got unexpected %d %t", v, ok)
+	}
+	if v, ok := e.Max(); ok {
+		t.Fatalf("e.Max() got unexpected %d %t", v, ok)
+	}
+
+	nan := math.NaN()
+	f := NewSortedSet(2.5, nan, -1.0)
+	if v, ok := f.Min(); !ok || !math.IsNaN(v) {
+		t.Fatalf("f.Min() got unexpected %f %t", v, ok)
+	}
+	if v, ok := f.Lower(-1); !ok || !math.IsNaN(v) {
+		t.Fatalf("f.Lower(-1) got unexpected %f %t", v, ok)
+	}
+	if r := f.Range(nan, 0, false, true).ToList(); !reflect.DeepEqual(r, []float64{-1}) {
+		t.Fatalf("f.Range(NaN, 0, false, true) got unexpected %v", r)
+	}
+}
+
+func TestSortedSetRank(t *testing.T) {
+	vals := rand.Perm(1000)
+	s := NewSortedSet(vals...)
+	s.Delete(0, 500, 999)
+	checkSkipList(t, s.u.list)
+
+	want := s.ToList()
+	for i, v := range want {
+		if r, ok := s.Rank(v); !ok || r != i {
+			t.Fatalf("s.Rank(%d) got unexpected %d %t", v, r, ok)
+		}
+		if r := s.At(i); r != v {
+			t.Fatalf("s.At(%d) got unexpected %d", i, r)
+		}
+	}
+	if r, ok := s.Rank(500); ok {
+		t.Fatalf("s.Rank(500) got unexpected %d %t", r, ok)
+	}
+	if r := s.Slice(10, 20); !reflect.DeepEqual(r, want[10:20]) {
+		t.Fatalf("s.Slice(10, 20) got unexpected %v", r)
+	}
+	if r := s.Slice(-5, 3); !reflect.DeepEqual(r, want[:3]) {
+		t.Fatalf("s.Slice(-5, 3) got unexpected %v", r)
+	}
+	if r := s.Slice(990, 2000); !reflect.DeepEqual(r, want[990:]) {
+		t.Fatalf("s.Slice(990, 2000) got unexpected %v", r)
+	}
+	if r := s.Slice(5, 5); r != nil {
+		t.Fatalf("s.Slice(5, 5) got unexpected %v", r)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("s.At(%d) didn't panic", s.Length())
+		}
+	}()
+	s.At(s.Length())
+}
+
+func TestSortedSetFunc(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+	byAge := func(a, b user) int {
+		return cmp.Compare(a.age, b.age)
+	}
+	tom, jerry, mickey := user{"Tom", 20}, user{"Jerry", 18}, user{"Mickey", 10}
+	s := NewSortedSetFunc(byAge, tom, jerry, mickey)
+	if r := s.ToList(); !reflect.DeepEqual(r, []user{mickey, jerry, tom}) {
+		t.Fatalf("s.ToList() got unexpected %v", r)
+	}
+	if r, ok := s.Rank(tom); !ok || r != 2 {
+		t.Fatalf("s.Rank(tom) got unexpected %d %t", r, ok)
+	}
+	if r, ok := s.Floor(user{age: 19}); !ok || r != jerry {
+		t.Fatalf("s.Floor(age 19) got unexpected %v %t", r, ok)
+	}
+	if r := s.Union(NewSortedSetFunc(byAge, user{"Tiana", 21})).ToList(); !reflect.DeepEqual(r, []user{mickey, jerry, tom, {"Tiana", 21}}) {
+		t.Fatalf("s.Union() got unexpected %v", r)
+	}
+
+	desc := NewSortedSetFunc(func(a, b int) int {
+		return cmp.Compare(b, a)
+	}, 3, 1, 2)
+	if r := desc.ToList(); !reflect.DeepEqual(r, []int{3, 2, 1}) {
+		t.Fatalf("desc.ToList() got unexpected %v", r)
+	}
+	if r, ok := desc.Min(); !ok || r != 3 {
+		t.Fatalf("desc.Min() got unexpected %d %t", r, ok)
+	}
+	if r := desc.Range(3, 2, false, true).ToList(); !reflect.DeepEqual(r, []int{2}) {
+		t.Fatalf("desc.Range(3, 2, false, true) got unexpected %v", r)
+	}
+
+	fold := NewSortedSetFunc(func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, "b", "A", "a", "B", "c")
+	if r := fold.ToList(); !reflect.DeepEqual(r, []string{"A", "b", "c"}) {
+		t.Fatalf("fold.ToList() got unexpected %v", r)
+	}
+	if !fold.Has("C") {
+		t.Fatalf("fold.Has(\"C\") got unexpected false")
+	}
+}
+
+// readOnly hides the concrete type of a set, so that operations can't take fast paths for it
+type readOnly[T comparable] struct {
+	ReadableSet[T]
+}
+
+func TestSortedSetMerge(t *testing.T) {
+	a := NewSortedSet(rand.Perm(300)...)
+	a.DeleteRange(100, 150)
+	b := NewSortedSet(rand.Perm(500)...)
+	b.DeleteRange(0, 50)
+	b.DeleteRange(250, 400)
+
+	for _, c := range []struct {
+		name   string
+		merged *SortedSet[int]
+		want   *SortedSet[int]
+	}{
+		{"Union", a.Union(b), a.Union(readOnly[int]{b})},
+		{"Intersect", a.Intersect(b), a.Intersect(readOnly[int]{b})},
+		{"Subtract", a.Subtract(b), a.Subtract(readOnly[int]{b})},
+		{"Complement", a.Complement(b), a.Complement(readOnly[int]{b})},
+	} {
+		checkSkipList(t, c.merged.u.list)
+		if !reflect.DeepEqual(c.merged.ToList(), c.want.ToList()) {
+			t.Fatalf("a.%s(b) got unexpected %v", c.name, c.merged.ToList())
+		}
+	}
+
+	// b is ordered by another comparator, so it can't be merged
+	desc := NewSortedSetFunc(func(x, y int) int {
+		return cmp.Compare(y, x)
+	}, b.ToList()...)
+	if r := a.Union(desc); !reflect.DeepEqual(r.ToList(), a.Union(b).ToList()) {
+		t.Fatalf("a.Union(desc) got unexpected %v", r.ToList())
+	}
+	if r := a.Intersect(desc); !reflect.DeepEqual(r.ToList(), a.Intersect(b).ToList()) {
+		t.Fatalf("a.Intersect(desc) got unexpected %v", r.ToList())
+	}
+}
+
+// addSortedLinear is the former SortedSet insertion on linearSet, kept as the baseline of benchmarks
+func addSortedLinear[T cmp.Ordered](s *linearSet[T], vals ...T) {
+	if len(vals) == 0 {
+		return
+	}
+	defer s.m.Unlock()
+	s.m.Lock()
+
+	var i int
+	if s.head == nil {
+		// first node
+		n := &setNode[T]{
+			val: vals[i],
+		}
+		s.head = n
+		s.tail = n
+		s.data[vals[i]] = n
+		i++
+	}
+	for ; i < len(vals); i++ {
+		if _, ok := s.data[vals[i]]; !ok {
+			n := &setNode[T]{
+				val: vals[i],
+			}
+			if cmp.Less(vals[i], s.head.val) {
+				// add to head
+				n.next = s.head
+				s.head.pre = n
+				s.head = n
+				s.data[vals[i]] = n
+			} else if cmp.Less(s.tail.val, vals[i]) {
+				//	add to tail
+				n.pre = s.tail
+				s.tail.next = n
+				s.tail = n
+				s.data[vals[i]] = n
+			} else {
+				// search and insert
+				left := s.head
+				right := left.next
+				for right != nil {
+					if cmp.Less(vals[i], right.val) {
+						// insert and break
+						left.next = n
+						right.pre = n
+						n.pre = left
+						n.next = right
+						s.data[vals[i]] = n
+						break
+					}
+					// go on
+					right = right.next
+					left = left.next
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSortedSetAdd(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		vals := rand.Perm(n)
+		b.Run(fmt.Sprintf("SkipList/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewSortedSet(vals...)
+			}
+		})
+		if n > 10000 {
+			// too slow to be measured
+			continue
+		}
+		b.Run(fmt.Sprintf("Linear/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				newLinearSet[int](addSortedLinear[int], vals...)
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSetHas(b *testing.B) {
+	const n = 10000
+	vals := rand.Perm(n)
+	s := NewSortedSet(vals...)
+	l := newLinearSet[int](addSortedLinear[int], vals...)
+	b.Run("SkipList", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.Has(i % n)
+		}
+	})
+	b.Run("Linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			l.Has(i % n)
+		}
+	})
+}
+
+func BenchmarkSortedSetDelete(b *testing.B) {
+	const n = 10000
+	vals := rand.Perm(n)
+	b.Run("SkipList", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			s := NewSortedSet(vals...)
+			b.StartTimer()
+			s.Delete(vals...)
+		}
+	})
+	b.Run("Linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			l := newLinearSet[int](addSortedLinear[int], vals...)
+			b.StartTimer()
+			l.Delete(vals...)
+		}
+	})
+}
+
+func BenchmarkSortedSetUnion(b *testing.B) {
+	const n = 10000
+	x := NewSortedSet(rand.Perm(n)...)
+	y := NewSortedSet(rand.Perm(n * 2)[n/2 : n+n/2]...)
+	b.Run("Merge", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Union(y)
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Union(readOnly[int]{y})
+		}
+	})
+}
+
+func BenchmarkSortedSetIntersect(b *testing.B) {
+	const n = 10000
+	x := NewSortedSet(rand.Perm(n)...)
+	y := NewSortedSet(rand.Perm(n * 2)[n/2 : n+n/2]...)
+	b.Run("Merge", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Intersect(y)
+		}
+	})
+	b.Run("Lookup", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Intersect(readOnly[int]{y})
+		}
+	})
+}
+
+func BenchmarkSortedSetComplement(b *testing.B) {
+	const n = 10000
+	x := NewSortedSet(rand.Perm(n)...)
+	y := NewSortedSet(rand.Perm(n * 2)[n/2 : n+n/2]...)
+	b.Run("Merge", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Complement(y)
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Complement(readOnly[int]{y})
+		}
+	})
+}